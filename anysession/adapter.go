@@ -0,0 +1,89 @@
+package anysession
+
+import (
+	"context"
+	"strconv"
+
+	gsession "github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	wssession "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+)
+
+type gateSession struct {
+	s gsession.Session
+}
+
+// FromGate adapts a session of the gate
+func FromGate(s gsession.Session) Session {
+	return &gateSession{s: s}
+}
+
+func (g *gateSession) ID() string {
+	return strconv.FormatInt(g.s.Options().Sid, 10)
+}
+func (g *gateSession) ServerID() string {
+	return g.s.Options().Fid
+}
+func (g *gateSession) UID() string {
+	return g.s.Options().Uid
+}
+func (g *gateSession) Bind(ctx context.Context, uid string) error {
+	return g.s.BindCtx(ctx, uid)
+}
+func (g *gateSession) Push(ctx context.Context, route string, v interface{}) error {
+	return g.s.PushCtx(ctx, route, v)
+}
+func (g *gateSession) Kick(ctx context.Context) error {
+	return g.s.KickCtx(ctx)
+}
+func (g *gateSession) Transport() string {
+	return TransportGate
+}
+func (g *gateSession) String() string {
+	return "anysession"
+}
+
+// uidStatus is the status key holding the uid of a websocket session
+const uidStatus = "uid"
+
+type wsSession struct {
+	s wssession.Session
+}
+
+// FromWebsocket adapts a session of the websocket service, its uid is the
+// "uid" key of the status
+func FromWebsocket(s wssession.Session) Session {
+	return &wsSession{s: s}
+}
+
+func (w *wsSession) ID() string {
+	return w.s.Options().SessionID
+}
+func (w *wsSession) ServerID() string {
+	return w.s.Options().ServerID
+}
+func (w *wsSession) UID() string {
+	return w.s.Status().GetString(uidStatus)
+}
+
+// Bind merges the uid into the status of the websocket session
+func (w *wsSession) Bind(ctx context.Context, uid string) error {
+	if uid == "" {
+		return gsession.ErrIllegalUID
+	}
+	if w.UID() != "" {
+		return gsession.ErrSessionAlreadyBound
+	}
+	return w.s.SetStatus(ctx, map[string]interface{}{uidStatus: uid})
+}
+func (w *wsSession) Push(ctx context.Context, route string, v interface{}) error {
+	return w.s.SendCtx(ctx, route, v)
+}
+func (w *wsSession) Kick(ctx context.Context) error {
+	return w.s.KickCtx(ctx)
+}
+func (w *wsSession) Transport() string {
+	return TransportWebsocket
+}
+func (w *wsSession) String() string {
+	return "anysession"
+}
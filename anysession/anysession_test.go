@@ -0,0 +1,64 @@
+package anysession_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wolfplus2048/mcbeam-plugins/anysession/v3"
+	gsession "github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	gpb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	gtest "github.com/wolfplus2048/mcbeam-plugins/session/v3/sessiontest"
+	wssession "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+	wstest "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/sessiontest"
+)
+
+func TestGate(t *testing.T) {
+	gate := gtest.NewGate()
+	s := anysession.FromGate(gate.NewSession(gsession.Sid(7), gsession.Fid("gate-1")))
+	if s.ID() != "7" || s.ServerID() != "gate-1" || s.Transport() != anysession.TransportGate {
+		t.Fatalf("unexpected session %s/%s/%s", s.ID(), s.ServerID(), s.Transport())
+	}
+	ctx := context.Background()
+	if err := s.Bind(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if s.UID() != "u1" {
+		t.Fatalf("uid %q", s.UID())
+	}
+	gate.ExpectBind(t, "u1")
+	if err := s.Push(ctx, "room.onJoin", &gpb.Session{Uid: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	gate.ExpectPush(t, "u1", "room.onJoin")
+	if anysession.Gate(s) == nil || anysession.Websocket(s) != nil {
+		t.Fatal("unexpected underlying session")
+	}
+}
+
+func TestWebsocket(t *testing.T) {
+	ws := wstest.NewService()
+	s := anysession.FromWebsocket(ws.NewSession(wssession.SessionID("s1")))
+	if s.ID() != "s1" || s.Transport() != anysession.TransportWebsocket {
+		t.Fatalf("unexpected session %s/%s", s.ID(), s.Transport())
+	}
+	ctx := context.Background()
+	if err := s.Bind(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if st := ws.Status("s1"); st.GetString("uid") != "u1" {
+		t.Fatalf("status %v", st)
+	}
+	if s.UID() != "u1" {
+		t.Fatalf("uid %q", s.UID())
+	}
+	if err := s.Bind(ctx, "u2"); err != gsession.ErrSessionAlreadyBound {
+		t.Fatalf("expected %v, got %v", gsession.ErrSessionAlreadyBound, err)
+	}
+	if err := s.Kick(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ws.ExpectKick(t, "s1")
+	if anysession.Websocket(s) == nil || anysession.Gate(s) != nil {
+		t.Fatal("unexpected underlying session")
+	}
+}
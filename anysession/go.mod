@@ -0,0 +1,11 @@
+module github.com/wolfplus2048/mcbeam-plugins/anysession/v3
+
+go 1.14
+
+require (
+	github.com/micro/micro/v3 v3.3.0
+	github.com/wolfplus2048/mcbeam-plugins/session/v3 v3.0.0-20261018060017-dbe66deacbac
+	github.com/wolfplus2048/mcbeam-plugins/ws_session/v3 v3.0.0-20261018060017-dbe66deacbac
+)
+
+replace github.com/micro/micro/v3 v3.3.0 => github.com/wolfplus2048/micro/v3 v3.3.0-mcbeam
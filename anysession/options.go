@@ -0,0 +1,25 @@
+package anysession
+
+import (
+	gsession "github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	wssession "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+)
+
+type Options struct {
+	// GateOptions are applied to every gate session
+	GateOptions []gsession.Option
+	// WebsocketOptions are applied to every websocket session
+	WebsocketOptions []wssession.Option
+}
+type Option func(o *Options)
+
+func GateOptions(opts ...gsession.Option) Option {
+	return func(o *Options) {
+		o.GateOptions = append(o.GateOptions, opts...)
+	}
+}
+func WebsocketOptions(opts ...wssession.Option) Option {
+	return func(o *Options) {
+		o.WebsocketOptions = append(o.WebsocketOptions, opts...)
+	}
+}
@@ -0,0 +1,58 @@
+// Package anysession puts the sessions of the gate (session) and of the
+// websocket service (ws_session) behind one interface, so a backend serving
+// both kinds of players reads a single context key.
+package anysession
+
+import (
+	"context"
+
+	gsession "github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	wssession "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+)
+
+const (
+	TransportGate      = "gate"
+	TransportWebsocket = "websocket"
+)
+
+// Session is the part of the API both transports share
+type Session interface {
+	// ID of the session on its server, the gate sid is formatted in base 10
+	ID() string
+	// ServerID is the frontend holding the connection
+	ServerID() string
+	// UID is the user bound to the session, empty until Bind
+	UID() string
+	Bind(ctx context.Context, uid string) error
+	Push(ctx context.Context, route string, v interface{}) error
+	Kick(ctx context.Context) error
+	// Transport is TransportGate or TransportWebsocket
+	Transport() string
+	String() string
+}
+
+type SessionCtxKey struct{}
+
+func GetSessionFromCtx(ctx context.Context) Session {
+	s, ok := ctx.Value(SessionCtxKey{}).(Session)
+	if ok {
+		return s
+	}
+	return nil
+}
+
+// Gate returns the gate session behind s, nil if it's a websocket one
+func Gate(s Session) gsession.Session {
+	if g, ok := s.(*gateSession); ok {
+		return g.s
+	}
+	return nil
+}
+
+// Websocket returns the websocket session behind s, nil if it's a gate one
+func Websocket(s Session) wssession.Session {
+	if w, ok := s.(*wsSession); ok {
+		return w.s
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package wrapper
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/service/server"
+	"github.com/wolfplus2048/mcbeam-plugins/anysession/v3"
+	gsession "github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	gwrapper "github.com/wolfplus2048/mcbeam-plugins/session/v3/wrapper"
+	wssession "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+	wswrapper "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/wrapper"
+)
+
+// SessionHandler recognises the metadata of both the gate (mcb-session-*) and
+// the websocket service (micro-ws-session-*) and places an anysession.Session
+// in the context. The transport specific sessions are placed under their own
+// keys as well, so handlers written for one of them keep working.
+func SessionHandler(opts ...anysession.Option) server.HandlerWrapper {
+	var options anysession.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	gate := gwrapper.SessionHandler(options.GateOptions...)
+	ws := wswrapper.SessionHandler(options.WebsocketOptions...)
+
+	return func(h server.HandlerFunc) server.HandlerFunc {
+		unify := func(ctx context.Context, req server.Request, rsp interface{}) error {
+			if s := gsession.GetSessionFromCtx(ctx); s != nil {
+				ctx = context.WithValue(ctx, anysession.SessionCtxKey{}, anysession.FromGate(s))
+			} else if s := wssession.GetSessionFromCtx(ctx); s != nil {
+				ctx = context.WithValue(ctx, anysession.SessionCtxKey{}, anysession.FromWebsocket(s))
+			}
+			return h(ctx, req, rsp)
+		}
+		return gate(ws(unify))
+	}
+}
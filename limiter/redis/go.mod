@@ -0,0 +1,11 @@
+module github.com/wolfplus2048/mcbeam-plugins/limiter/redis/v3
+
+go 1.14
+
+require (
+	github.com/go-redis/redis/v8 v8.8.2
+	github.com/micro/micro/v3 v3.3.0
+	github.com/wolfplus2048/mcbeam-plugins/session/v3 v3.0.0-20261018060017-dbe66deacbac
+)
+
+replace github.com/micro/micro/v3 v3.3.0 => github.com/wolfplus2048/micro/v3 v3.2.0-mcbeam.0.20210804071852-fbef4a5fc3eb
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
+)
+
+type clientKey struct{}
+
+// Client sets the redis client holding the buckets
+func Client(c redis.UniversalClient) limiter.Option {
+	return func(o *limiter.Options) {
+		if nil == o.Context {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, clientKey{}, c)
+	}
+}
+
+type nodesKey struct{}
+
+// Nodes sets the address of the redis server when no Client is set, a
+// redis:// url or host:port, 127.0.0.1:6379 by default
+func Nodes(nodes ...string) limiter.Option {
+	return func(o *limiter.Options) {
+		if nil == o.Context {
+			o.Context = context.Background()
+		}
+		o.Context = context.WithValue(o.Context, nodesKey{}, nodes)
+	}
+}
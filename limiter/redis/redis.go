@@ -0,0 +1,83 @@
+// Package redis is a limiter keeping the token buckets in redis, so every
+// instance of a service takes from the same bucket
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
+)
+
+// allow refills the bucket KEYS[1] for the time elapsed since its last use
+// and takes a token from it. ARGV: rate per second, burst, now in ms.
+var allow = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local b = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+if now > ts then
+	tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+end
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return allowed
+`)
+
+type redisLimiter struct {
+	opts   limiter.Options
+	client redis.UniversalClient
+}
+
+func (r *redisLimiter) Options() limiter.Options {
+	return r.opts
+}
+
+func (r *redisLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	n, err := allow.Run(ctx, r.client, []string{r.opts.Prefix + key}, r.opts.Rate, r.opts.Burst, now).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *redisLimiter) String() string {
+	return "redis"
+}
+
+// NewLimiter returns a limiter keeping the buckets in redis, the clocks of
+// the instances sharing them should be in sync. A rate that is not positive
+// would never expire the buckets, the default one is used instead
+func NewLimiter(opts ...limiter.Option) limiter.Limiter {
+	options := limiter.NewOptions(opts...)
+	if options.Rate <= 0 {
+		rate := limiter.NewOptions().Rate
+		logger.Warnf("redis limiter rate:%v is not positive, use %v", options.Rate, rate)
+		options.Rate = rate
+	}
+	r := &redisLimiter{opts: options}
+	if c, ok := options.Context.Value(clientKey{}).(redis.UniversalClient); ok {
+		r.client = c
+		return r
+	}
+	nodes, _ := options.Context.Value(nodesKey{}).([]string)
+	if len(nodes) == 0 {
+		nodes = []string{"redis://127.0.0.1:6379"}
+	}
+	redisOptions, err := redis.ParseURL(nodes[0])
+	if err != nil {
+		redisOptions = &redis.Options{Addr: nodes[0]}
+	}
+	r.client = redis.NewClient(redisOptions)
+	return r
+}
@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
+)
+
+func Test_NewLimiterRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		l := NewLimiter(limiter.Rate(rate))
+		if got, want := l.Options().Rate, limiter.NewOptions().Rate; got != want {
+			t.Errorf("rate %v: Options().Rate = %v, want %v", rate, got, want)
+		}
+	}
+	if got := NewLimiter(limiter.Rate(5)).Options().Rate; got != 5 {
+		t.Errorf("Options().Rate = %v, want 5", got)
+	}
+}
+
+func Test_Limiter(t *testing.T) {
+	if tr := os.Getenv("TRAVIS"); len(tr) > 0 {
+		t.Skip()
+	}
+	l := NewLimiter(Nodes("redis://127.0.0.1:6379"), limiter.Rate(100), limiter.Burst(3))
+	ctx := context.Background()
+	key := "test-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	for i := 0; i < 3; i++ {
+		if ok, err := l.Allow(ctx, key); err != nil || !ok {
+			t.Fatalf("Allow() #%d = %v, %v", i, ok, err)
+		}
+	}
+	if ok, err := l.Allow(ctx, key); err != nil || ok {
+		t.Fatalf("Allow() = %v, %v with an empty bucket", ok, err)
+	}
+	if ok, err := l.Allow(ctx, key+"-other"); err != nil || !ok {
+		t.Fatalf("Allow() = %v, %v for another key", ok, err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if ok, err := l.Allow(ctx, key); err != nil || !ok {
+		t.Fatalf("Allow() = %v, %v after the bucket refilled", ok, err)
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
+// PushToUIDs sends one message to many users bound on the frontend fid with a
+// single call to the gate. It returns the uids the gate could not deliver to
+// because they were not online. The options select the gate, codec and route
+// dictionary, the session fields are unused.
+func PushToUIDs(ctx context.Context, fid, route string, uids []string, v interface{}, opts ...session.Option) ([]string, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	return pushBatch(ctx, fid, &pb.PushBatchMsg{Route: route, Uids: uids}, v, opts)
+}
+
+// Broadcast sends one message to every bound session of the frontend fid
+func Broadcast(ctx context.Context, fid, route string, v interface{}, opts ...session.Option) error {
+	_, err := pushBatch(ctx, fid, &pb.PushBatchMsg{Route: route, All: true}, v, opts)
+	return err
+}
+
+func pushBatch(ctx context.Context, fid string, msg *pb.PushBatchMsg, v interface{}, opts []session.Option) ([]string, error) {
+	var options session.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	c := options.Codec
+	if c == nil {
+		c = codec.DefaultMarshaler
+	}
+	b, err := c.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	msg.Data = b
+	routes := options.Routes
+	if routes == nil {
+		routes = route.DefaultDictionary
+	}
+	if code, ok := routes.Code(msg.Route); ok {
+		msg.Route, msg.RouteCode = "", uint32(code)
+	}
+	rsp, err := newGateService(options).PushBatch(ctx, msg, callOptions(options, fid)...)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.FailedUids, nil
+}
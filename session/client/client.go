@@ -1,24 +1,33 @@
 package client
 
 import (
+	"context"
+
 	"github.com/micro/micro/v3/service/client"
-	"github.com/micro/micro/v3/service/context"
+	mcontext "github.com/micro/micro/v3/service/context"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
-	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/directory"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
 	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
 )
 
 type srv struct {
+	*session.Data
 	opts session.Options
 	gate pb.McbGateService
-	data map[string]interface{}
 }
 
 func (s *srv) Init(opts ...session.Option) {
 	for _, o := range opts {
 		o(&s.opts)
 	}
+	s.configure()
+}
+func (s *srv) configure() {
+	s.gate = newGateService(s.opts)
 }
 func (s *srv) Options() session.Options {
 	return s.opts
@@ -29,7 +38,28 @@ func (s *srv) String() string {
 func (s *srv) UID() string {
 	return s.opts.Uid
 }
+func (s *srv) context() context.Context {
+	if s.opts.Context != nil {
+		return s.opts.Context
+	}
+	return mcontext.DefaultContext
+}
+func (s *srv) codec() codec.Marshaler {
+	if s.opts.Codec != nil {
+		return s.opts.Codec
+	}
+	return codec.DefaultMarshaler
+}
+func (s *srv) routes() *route.Dictionary {
+	if s.opts.Routes != nil {
+		return s.opts.Routes
+	}
+	return route.DefaultDictionary
+}
 func (s *srv) Bind(uid string) error {
+	return s.BindCtx(s.context(), uid)
+}
+func (s *srv) BindCtx(ctx context.Context, uid string) error {
 	logger.Infof("bind fid:%s, sid:%s, uid:%s", s.opts.Fid, s.opts.Sid, uid)
 	if uid == "" {
 		return session.ErrIllegalUID
@@ -38,27 +68,82 @@ func (s *srv) Bind(uid string) error {
 	if s.UID() != "" {
 		return session.ErrSessionAlreadyBound
 	}
+	if s.opts.DuplicateLogin == session.LoginAllowMulti {
+		return s.bind(ctx, uid)
+	}
+	return s.bindOnce(ctx, uid)
+}
+func (s *srv) bind(ctx context.Context, uid string) error {
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
 	s.opts.Uid = uid
 	sessionData := &pb.Session{
-		Id:  s.opts.Sid,
-		Uid: s.opts.Uid,
+		Id:   s.opts.Sid,
+		Uid:  s.opts.Uid,
+		Data: data,
 	}
-	rsp, err := s.gate.Bind(context.DefaultContext, sessionData, client.WithServerUid(s.opts.Fid), client.WithAuthToken())
+	rsp, err := s.gate.Bind(ctx, sessionData, callOptions(s.opts, s.opts.Fid)...)
 	logger.Infof("bind: rsp:%v, err:%v", rsp, err)
 	return err
 }
 func (s *srv) Kick() error {
+	return s.KickCtx(s.context())
+}
+func (s *srv) KickCtx(ctx context.Context) error {
+	_, err := s.KickWith(ctx)
+	return err
+}
+func (s *srv) KickWith(ctx context.Context, opts ...session.KickOption) (bool, error) {
 	if s.UID() == "" {
-		return session.ErrNoUIDBind
+		return false, session.ErrNoUIDBind
 	}
-	_, err := s.gate.Kick(context.DefaultContext, &pb.KickMsg{UserId: s.UID()}, client.WithServerUid(s.opts.Fid), client.WithAuthToken())
-	return err
+	var options session.KickOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	req := &pb.KickMsg{
+		UserId:  s.UID(),
+		Reason:  options.Reason,
+		Message: options.Message,
+	}
+	rsp, err := s.gate.Kick(ctx, req, callOptions(s.opts, s.opts.Fid)...)
+	if err != nil {
+		return false, err
+	}
+	return rsp.Kicked, nil
 }
 func (s *srv) PushSession() error {
-	return nil
+	return s.PushSessionCtx(s.context())
+}
+func (s *srv) PushSessionCtx(ctx context.Context) error {
+	data, err := s.Marshal()
+	if err != nil {
+		return err
+	}
+	sessionData := &pb.Session{
+		Id:   s.opts.Sid,
+		Uid:  s.UID(),
+		Data: data,
+	}
+	_, err = s.gate.PushSession(ctx, sessionData, callOptions(s.opts, s.opts.Fid)...)
+	return err
 }
 func (s *srv) Push(route string, v interface{}) error {
-	b, err := proto.Marshal(v)
+	return s.PushCtx(s.context(), route, v)
+}
+func (s *srv) PushCtx(ctx context.Context, route string, v interface{}) error {
+	if l := s.opts.PushLimiter; l != nil {
+		// the push goes on when the limiter fails
+		ok, err := l.Allow(ctx, limiter.Key(s.UID(), s.opts.Fid, s.opts.Sid))
+		if err != nil {
+			logger.Warnf("push limiter, uid:%s, err:%v", s.UID(), err)
+		} else if !ok {
+			return limiter.ErrLimited
+		}
+	}
+	b, err := s.codec().Marshal(v)
 	if err != nil {
 		return err
 	}
@@ -67,7 +152,13 @@ func (s *srv) Push(route string, v interface{}) error {
 		Uid:   s.UID(),
 		Data:  b,
 	}
-	rsp, err := s.gate.Push(context.DefaultContext, push, client.WithServerUid(s.opts.Fid), client.WithAuthToken())
+	if code, ok := s.routes().Code(route); ok {
+		push.Route, push.RouteCode = "", uint32(code)
+	}
+	if s.opts.Pusher != nil {
+		return s.opts.Pusher.Push(ctx, s.opts.Fid, push)
+	}
+	rsp, err := s.gate.Push(ctx, push, callOptions(s.opts, s.opts.Fid)...)
 	logger.Infof("bind: rsp:%v, err:%v", rsp, err)
 
 	return err
@@ -75,11 +166,60 @@ func (s *srv) Push(route string, v interface{}) error {
 
 func NewSession(opts ...session.Option) session.Session {
 	s := &srv{
-		data: make(map[string]interface{}),
-		gate: pb.NewMcbGateService("gate", client.DefaultClient),
+		Data: session.NewData(),
 	}
 	for _, o := range opts {
 		o(&s.opts)
 	}
+	s.configure()
 	return s
 }
+
+func newGateService(opts session.Options) pb.McbGateService {
+	if opts.Gate != nil {
+		return opts.Gate
+	}
+	name := opts.GateName
+	if len(name) == 0 {
+		name = "gate"
+	}
+	c := opts.Client
+	if c == nil {
+		c = client.DefaultClient
+	}
+	return pb.NewMcbGateService(name, c)
+}
+
+// callOptions routes a call to the frontend fid after the default options
+func callOptions(opts session.Options, fid string) []client.CallOption {
+	callOpts := make([]client.CallOption, 0, len(opts.CallOptions)+2)
+	callOpts = append(callOpts, opts.CallOptions...)
+	return append(callOpts, client.WithServerUid(fid), client.WithAuthToken())
+}
+
+// NewSessionByUID returns a session for a uid bound on any frontend, looking
+// up the frontend and the gate in the directory of opts, the one Bind
+// records the uid in, directory.DefaultDirectory when none is set
+func NewSessionByUID(uid string, opts ...session.Option) (session.Session, error) {
+	if uid == "" {
+		return nil, session.ErrIllegalUID
+	}
+	var options session.Options
+	for _, o := range opts {
+		o(&options)
+	}
+	dir := options.Directory
+	if dir == nil {
+		dir = directory.DefaultDirectory
+	}
+	e, err := dir.Lookup(uid)
+	if err != nil {
+		return nil, err
+	}
+	base := []session.Option{session.Uid(uid), session.Fid(e.Fid), session.Sid(e.Sid)}
+	if len(e.Gate) > 0 {
+		base = append(base, session.GateName(e.Gate))
+	}
+	opts = append(base, opts...)
+	return NewSession(opts...), nil
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/directory"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+func (s *srv) directory() directory.Directory {
+	if s.opts.Directory != nil {
+		return s.opts.Directory
+	}
+	return directory.DefaultDirectory
+}
+
+// bindOnce binds uid holding the lock of the directory, so two frontends
+// can't both see the uid free, the other session is rejected or kicked
+// according to the policy. LoginReject needs a directory whose entries
+// expire, the uid of a dead gate is free again after the expiry
+func (s *srv) bindOnce(ctx context.Context, uid string) error {
+	dir := s.directory()
+	if s.opts.DuplicateLogin == session.LoginReject && dir.Options().Expiry <= 0 {
+		return directory.ErrNoExpiry
+	}
+	if err := dir.Lock(uid); err != nil {
+		return err
+	}
+	defer func() {
+		if err := dir.Unlock(uid); err != nil {
+			logger.Warnf("unlock uid:%s, err:%v", uid, err)
+		}
+	}()
+
+	cur, err := dir.Lookup(uid)
+	if err != nil && err != directory.ErrNotFound {
+		return err
+	}
+	if cur != nil && (cur.Fid != s.opts.Fid || cur.Sid != s.opts.Sid) {
+		if s.opts.DuplicateLogin == session.LoginReject {
+			return session.ErrDuplicateLogin
+		}
+		if err := s.kickOld(ctx, uid, cur); err != nil {
+			return err
+		}
+	}
+	if err := s.bind(ctx, uid); err != nil {
+		return err
+	}
+	return dir.Bind(uid, &directory.Entry{Fid: s.opts.Fid, Sid: s.opts.Sid, Gate: s.opts.GateName})
+}
+
+// kickOld kicks uid from the gate of e, an entry left by a session which
+// closed without unbinding isn't an error, nor is a gate which can't be
+// reached: the entry is overwritten. Any other error of the kick fails the
+// bind, the old session may still be alive
+func (s *srv) kickOld(ctx context.Context, uid string, e *directory.Entry) error {
+	opts := s.opts
+	if len(e.Gate) > 0 {
+		opts.GateName = e.Gate
+	}
+	req := &pb.KickMsg{
+		UserId: uid,
+		Reason: pb.KickReason_KickDuplicateLogin,
+	}
+	rsp, err := newGateService(opts).Kick(ctx, req, callOptions(opts, e.Fid)...)
+	if err != nil {
+		if !unreachable(ctx, err) {
+			return err
+		}
+		logger.Warnf("kick old session, uid:%s, fid:%s, err:%v", uid, e.Fid, err)
+		return nil
+	}
+	if !rsp.Kicked {
+		logger.Infof("kick old session, uid:%s, fid:%s, not found", uid, e.Fid)
+	}
+	return nil
+}
+
+// unreachable tells if err is the client failing to reach the gate, rather
+// than the caller giving up or the gate answering with an error
+func unreachable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	e := merrors.FromError(err)
+	if e.Id != "go.micro.client" {
+		return false
+	}
+	switch e.Code {
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
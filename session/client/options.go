@@ -0,0 +1,53 @@
+package client
+
+import (
+	"time"
+
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+)
+
+type StreamOptions struct {
+	// QueueSize is the number of pushes buffered per frontend, Push blocks
+	// when it's full, 1024 when 0
+	QueueSize int
+	// Retries is how many times a push is sent again over a new stream
+	// before it's dropped, 3 when 0
+	Retries int
+	// Backoff is the wait before opening the stream again after a failure,
+	// from 100ms doubling up to 5s when nil
+	Backoff func(attempts int) time.Duration
+	// IdleTimeout closes the stream of a frontend nothing was pushed to for
+	// that long and forgets the frontend, it's opened again by the next
+	// push, 1m when 0
+	IdleTimeout time.Duration
+	// SessionOptions tell how to reach the gate, e.g. session.GateName,
+	// session.Client and session.CallOptions
+	SessionOptions []session.Option
+}
+type StreamOption func(o *StreamOptions)
+
+func QueueSize(n int) StreamOption {
+	return func(o *StreamOptions) {
+		o.QueueSize = n
+	}
+}
+func Retries(n int) StreamOption {
+	return func(o *StreamOptions) {
+		o.Retries = n
+	}
+}
+func Backoff(fn func(attempts int) time.Duration) StreamOption {
+	return func(o *StreamOptions) {
+		o.Backoff = fn
+	}
+}
+func IdleTimeout(d time.Duration) StreamOption {
+	return func(o *StreamOptions) {
+		o.IdleTimeout = d
+	}
+}
+func SessionOptions(opts ...session.Option) StreamOption {
+	return func(o *StreamOptions) {
+		o.SessionOptions = append(o.SessionOptions, opts...)
+	}
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+var _ session.Pusher = (*OrderedPusher)(nil)
+
+// OrderedPusher numbers the pushes of each uid from 1 in PushMsg.Seq and
+// delivers them one at a time, so the gate receives them in that order. The
+// numbers are kept by the pusher, two backends pushing to the same uid have
+// their own sequences. Set it with session.WithPusher.
+type OrderedPusher struct {
+	next session.Pusher
+	opts session.Options
+	gate pb.McbGateService
+
+	mtx  sync.Mutex
+	uids map[string]*sequence
+}
+
+type sequence struct {
+	mtx sync.Mutex
+	seq uint64
+}
+
+// NewOrderedPusher delivers the pushes with next, e.g. a StreamPusher, or
+// with a unary call to the gate reached with opts when next is nil
+func NewOrderedPusher(next session.Pusher, opts ...session.Option) *OrderedPusher {
+	p := &OrderedPusher{
+		next: next,
+		uids: make(map[string]*sequence),
+	}
+	for _, o := range opts {
+		o(&p.opts)
+	}
+	if next == nil {
+		p.gate = newGateService(p.opts)
+	}
+	return p
+}
+
+// Push stamps msg with the next seq of its uid and waits for the previous
+// pushes of the uid to be delivered first. A push which fails keeps its seq,
+// the client sees the gap and asks for a resync.
+func (p *OrderedPusher) Push(ctx context.Context, fid string, msg *pb.PushMsg) error {
+	if len(msg.Uid) == 0 {
+		return p.push(ctx, fid, msg)
+	}
+	seq := p.sequence(msg.Uid)
+	seq.mtx.Lock()
+	defer seq.mtx.Unlock()
+	seq.seq++
+	msg.Seq = seq.seq
+	return p.push(ctx, fid, msg)
+}
+
+// Forget drops the sequence of uid, e.g. when its session closes, the next
+// push to uid starts over from 1
+func (p *OrderedPusher) Forget(uid string) {
+	p.mtx.Lock()
+	delete(p.uids, uid)
+	p.mtx.Unlock()
+}
+
+func (p *OrderedPusher) sequence(uid string) *sequence {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	seq, ok := p.uids[uid]
+	if !ok {
+		seq = new(sequence)
+		p.uids[uid] = seq
+	}
+	return seq
+}
+
+func (p *OrderedPusher) push(ctx context.Context, fid string, msg *pb.PushMsg) error {
+	if p.next != nil {
+		return p.next.Push(ctx, fid, msg)
+	}
+	_, err := p.gate.Push(ctx, msg, callOptions(p.opts, fid)...)
+	return err
+}
+
+// GapDetector follows the seq of the pushes a client receives and calls
+// OnGap when some are missing, so the client can ask for a resync. Seq 1
+// starts a new sequence, e.g. after the backend restarted.
+type GapDetector struct {
+	// OnGap is called with the first and the last missing seq of uid
+	OnGap func(uid string, from, to uint64)
+
+	mtx  sync.Mutex
+	last map[string]uint64
+}
+
+func NewGapDetector(onGap func(uid string, from, to uint64)) *GapDetector {
+	return &GapDetector{
+		OnGap: onGap,
+		last:  make(map[string]uint64),
+	}
+}
+
+// Observe records the seq of msg, false when it's a duplicate or arrives
+// after a later one and should be dropped. Unordered pushes are accepted.
+func (d *GapDetector) Observe(msg *pb.PushMsg) bool {
+	if msg.Seq == 0 {
+		return true
+	}
+	d.mtx.Lock()
+	last := d.last[msg.Uid]
+	if msg.Seq != 1 && msg.Seq <= last {
+		d.mtx.Unlock()
+		return false
+	}
+	d.last[msg.Uid] = msg.Seq
+	d.mtx.Unlock()
+
+	if msg.Seq != 1 && msg.Seq > last+1 && d.OnGap != nil {
+		d.OnGap(msg.Uid, last+1, msg.Seq-1)
+	}
+	return true
+}
+
+// Reset forgets the seq of uid, e.g. after a resync
+func (d *GapDetector) Reset(uid string) {
+	d.mtx.Lock()
+	delete(d.last, uid)
+	d.mtx.Unlock()
+}
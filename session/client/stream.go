@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	mcontext "github.com/micro/micro/v3/service/context"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+var (
+	ErrPusherClosed = errors.New("stream pusher is closed")
+
+	// errStreamClosed is returned by a stream which went idle or is shut
+	// down, Push takes the stream of the frontend again
+	errStreamClosed = errors.New("push stream is closed")
+)
+
+var _ session.Pusher = (*StreamPusher)(nil)
+
+// StreamPusher sends the pushes of every session of a frontend over one
+// long-lived McbGate.PushStream, set it with session.WithPusher. A push is
+// queued and sent in order by the stream of its frontend; Push blocks while
+// the queue is full, which slows the backend down to the pace of the gate.
+// A broken stream is opened again and the failed push retried, the pushes
+// already sent over it may be lost.
+type StreamPusher struct {
+	opts  StreamOptions
+	sopts session.Options
+	gate  pb.McbGateService
+
+	mtx     sync.Mutex
+	streams map[string]*pushStream
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+func NewStreamPusher(opts ...StreamOption) *StreamPusher {
+	var options StreamOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = 1024
+	}
+	if options.Retries <= 0 {
+		options.Retries = 3
+	}
+	if options.Backoff == nil {
+		options.Backoff = backoff
+	}
+	if options.IdleTimeout <= 0 {
+		options.IdleTimeout = time.Minute
+	}
+	p := &StreamPusher{
+		opts:    options,
+		streams: make(map[string]*pushStream),
+	}
+	for _, o := range options.SessionOptions {
+		o(&p.sopts)
+	}
+	p.gate = newGateService(p.sopts)
+	return p
+}
+
+func (p *StreamPusher) Options() StreamOptions {
+	return p.opts
+}
+
+// Push queues msg on the stream of fid, it returns when msg is queued, not
+// when the gate received it
+func (p *StreamPusher) Push(ctx context.Context, fid string, msg *pb.PushMsg) error {
+	for {
+		st, err := p.stream(fid)
+		if err != nil {
+			return err
+		}
+		if err := st.push(ctx, msg); err != errStreamClosed {
+			return err
+		}
+	}
+}
+
+// Close sends the queued pushes and closes every stream
+func (p *StreamPusher) Close() error {
+	p.mtx.Lock()
+	if p.closed {
+		p.mtx.Unlock()
+		return nil
+	}
+	p.closed = true
+	streams := p.streams
+	p.streams = make(map[string]*pushStream)
+	p.mtx.Unlock()
+	for _, st := range streams {
+		st.shutdown()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+// remove forgets the idle stream st, a later push to its frontend starts a
+// new one
+func (p *StreamPusher) remove(st *pushStream) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.streams[st.fid] == st {
+		delete(p.streams, st.fid)
+	}
+}
+
+func (p *StreamPusher) stream(fid string) (*pushStream, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		return nil, ErrPusherClosed
+	}
+	if st, ok := p.streams[fid]; ok {
+		return st, nil
+	}
+	st := &pushStream{
+		p:     p,
+		fid:   fid,
+		queue: make(chan *pb.PushMsg, p.opts.QueueSize),
+		exit:  make(chan struct{}),
+	}
+	p.streams[fid] = st
+	p.wg.Add(1)
+	go st.run()
+	return st, nil
+}
+
+type pushStream struct {
+	p     *StreamPusher
+	fid   string
+	queue chan *pb.PushMsg
+	exit  chan struct{}
+
+	// mtx keeps a push from being queued once closed is set, when flush
+	// may have already returned
+	mtx    sync.RWMutex
+	closed bool
+	once   sync.Once
+
+	cancel context.CancelFunc
+	stream pb.McbGate_PushStreamService
+}
+
+func (st *pushStream) push(ctx context.Context, msg *pb.PushMsg) error {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+	if st.closed {
+		return errStreamClosed
+	}
+	select {
+	case st.queue <- msg:
+		return nil
+	case <-st.exit:
+		return errStreamClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shutdown stops the pushes and makes run flush the queue, closing exit
+// first wakes up the pushes blocked on a full queue so the lock is free
+func (st *pushStream) shutdown() {
+	st.once.Do(func() {
+		close(st.exit)
+	})
+	st.mtx.Lock()
+	st.closed = true
+	st.mtx.Unlock()
+}
+
+func (st *pushStream) run() {
+	defer st.p.wg.Done()
+	defer st.close()
+
+	idle := time.NewTimer(st.p.opts.IdleTimeout)
+	defer idle.Stop()
+	for {
+		select {
+		case msg := <-st.queue:
+			st.send(msg)
+		case <-idle.C:
+			if len(st.queue) > 0 {
+				idle.Reset(st.p.opts.IdleTimeout)
+				continue
+			}
+			// nothing to push for a while, the frontend may be gone
+			st.p.remove(st)
+			st.shutdown()
+			st.flush()
+			return
+		case <-st.exit:
+			st.shutdown()
+			st.flush()
+			return
+		}
+		if !idle.Stop() {
+			select {
+			case <-idle.C:
+			default:
+			}
+		}
+		idle.Reset(st.p.opts.IdleTimeout)
+	}
+}
+
+// send sends msg, opening the stream again on failure
+func (st *pushStream) send(msg *pb.PushMsg) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && !st.sleep(st.p.opts.Backoff(attempt)) {
+			return
+		}
+		if st.stream == nil {
+			if err := st.open(); err != nil {
+				logger.Warnf("open push stream, fid:%s, err:%v", st.fid, err)
+				if attempt >= st.p.opts.Retries {
+					logger.Errorf("drop push, fid:%s, uid:%s, route:%s", st.fid, msg.Uid, msg.Route)
+					return
+				}
+				continue
+			}
+		}
+		err := st.stream.Send(msg)
+		if err == nil {
+			return
+		}
+		logger.Warnf("push stream, fid:%s, err:%v", st.fid, err)
+		st.close()
+		if attempt >= st.p.opts.Retries {
+			logger.Errorf("drop push, fid:%s, uid:%s, route:%s", st.fid, msg.Uid, msg.Route)
+			return
+		}
+	}
+}
+
+// flush sends what is left in the queue without retrying
+func (st *pushStream) flush() {
+	for {
+		select {
+		case msg := <-st.queue:
+			if st.stream == nil {
+				if err := st.open(); err != nil {
+					logger.Warnf("open push stream, fid:%s, err:%v", st.fid, err)
+					return
+				}
+			}
+			if err := st.stream.Send(msg); err != nil {
+				logger.Warnf("push stream, fid:%s, err:%v", st.fid, err)
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (st *pushStream) open() error {
+	ctx, cancel := context.WithCancel(mcontext.DefaultContext)
+	stream, err := st.p.gate.PushStream(ctx, callOptions(st.p.sopts, st.fid)...)
+	if err != nil {
+		cancel()
+		return err
+	}
+	st.stream, st.cancel = stream, cancel
+	return nil
+}
+
+func (st *pushStream) close() {
+	if st.stream == nil {
+		return
+	}
+	if _, err := st.stream.CloseAndRecv(); err != nil {
+		logger.Infof("close push stream, fid:%s, err:%v", st.fid, err)
+	}
+	st.cancel()
+	st.stream, st.cancel = nil, nil
+}
+
+// sleep waits for d, false if the pusher is closed in the meantime
+func (st *pushStream) sleep(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-st.exit:
+		return false
+	}
+}
+
+func backoff(attempts int) time.Duration {
+	d := 100 * time.Millisecond
+	for i := 1; i < attempts && d < 5*time.Second; i++ {
+		d *= 2
+	}
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	return d
+}
@@ -0,0 +1,44 @@
+// Package codec defines the marshalers used to encode pushes and messages
+// exchanged with the clients through the gate. proto is the default, json and
+// msgpack are registered too and more can be added with Register.
+package codec
+
+import (
+	"sync"
+
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/json"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/msgpack"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/proto"
+)
+
+type Marshaler interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	String() string
+}
+
+var (
+	DefaultMarshaler Marshaler = proto.Marshaler{}
+
+	mtx        sync.RWMutex
+	marshalers = map[string]Marshaler{
+		"proto":   proto.Marshaler{},
+		"json":    json.Marshaler{},
+		"msgpack": msgpack.Marshaler{},
+	}
+)
+
+// Register makes a marshaler available by its String() name
+func Register(m Marshaler) {
+	mtx.Lock()
+	marshalers[m.String()] = m
+	mtx.Unlock()
+}
+
+// Get returns the marshaler registered under name
+func Get(name string) (Marshaler, bool) {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	m, ok := marshalers[name]
+	return m, ok
+}
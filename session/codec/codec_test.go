@@ -0,0 +1,48 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+type player struct {
+	Name  string `json:"name" msgpack:"name"`
+	Level int    `json:"level" msgpack:"level"`
+}
+
+func TestMarshalers(t *testing.T) {
+	for _, name := range []string{"json", "msgpack"} {
+		m, ok := Get(name)
+		if !ok {
+			t.Fatalf("%s is not registered", name)
+		}
+		in := &player{Name: "p1", Level: 3}
+		b, err := m.Marshal(in)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := new(player)
+		if err := m.Unmarshal(b, out); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(in, out) {
+			t.Errorf("%s: got %+v, want %+v", name, out, in)
+		}
+	}
+
+	// json keeps the protobuf field names of proto messages
+	m, _ := Get("json")
+	b, err := m.Marshal(&pb.KickMsg{UserId: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"userId":"u1"}` {
+		t.Errorf("json proto = %s", b)
+	}
+
+	if _, err := DefaultMarshaler.Marshal(&player{}); err == nil {
+		t.Errorf("proto marshaled a plain struct")
+	}
+}
@@ -0,0 +1,30 @@
+package msgpack
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var defaultMarshaler Marshaler
+
+// Marshaler encodes plain Go values with msgpack, struct fields can be
+// renamed with `msgpack:"name"` tags
+type Marshaler struct{}
+
+func (Marshaler) Marshal(v interface{}) ([]byte, error) {
+	return msgpack.Marshal(v)
+}
+
+func (Marshaler) Unmarshal(data []byte, v interface{}) error {
+	return msgpack.Unmarshal(data, v)
+}
+
+func (Marshaler) String() string {
+	return "msgpack"
+}
+func Marshal(v interface{}) ([]byte, error) {
+	return defaultMarshaler.Marshal(v)
+}
+
+func Unmarshal(data []byte, v interface{}) error {
+	return defaultMarshaler.Unmarshal(data, v)
+}
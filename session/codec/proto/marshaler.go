@@ -49,7 +49,8 @@ func (Marshaler) Marshal(v interface{}) ([]byte, error) {
 		return nil, err
 	}
 
-	return pbuf.Bytes(), nil
+	// the buffer goes back to the pool, hand out a copy
+	return append([]byte(nil), pbuf.Bytes()...), nil
 }
 
 func (Marshaler) Unmarshal(data []byte, v interface{}) error {
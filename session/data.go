@@ -0,0 +1,201 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"sync"
+)
+
+// Data is the key/value state attached to a session. It travels to and from
+// the gate JSON encoded in pb.Session.Data, so values should be JSON friendly.
+type Data struct {
+	mtx    sync.RWMutex
+	values map[string]interface{}
+}
+
+func NewData() *Data {
+	return &Data{values: make(map[string]interface{})}
+}
+
+func (d *Data) Set(key string, v interface{}) {
+	d.mtx.Lock()
+	d.values[key] = v
+	d.mtx.Unlock()
+}
+
+func (d *Data) Get(key string) interface{} {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return d.values[key]
+}
+
+func (d *Data) Remove(key string) {
+	d.mtx.Lock()
+	delete(d.values, key)
+	d.mtx.Unlock()
+}
+
+func (d *Data) HasKey(key string) bool {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	_, ok := d.values[key]
+	return ok
+}
+
+// SetData replaces all the values
+func (d *Data) SetData(data map[string]interface{}) {
+	values := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		values[k] = v
+	}
+	d.mtx.Lock()
+	d.values = values
+	d.mtx.Unlock()
+}
+
+// GetData returns a copy of all the values
+func (d *Data) GetData() map[string]interface{} {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	data := make(map[string]interface{}, len(d.values))
+	for k, v := range d.values {
+		data[k] = v
+	}
+	return data
+}
+
+func (d *Data) GetInt(key string) int {
+	return int(d.GetInt64(key))
+}
+
+func (d *Data) GetInt64(key string) int64 {
+	switch v := d.Get(key).(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case json.Number:
+		i, _ := v.Int64()
+		return i
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
+
+func (d *Data) GetFloat64(key string) float64 {
+	switch v := d.Get(key).(type) {
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+func (d *Data) GetBool(key string) bool {
+	switch v := d.Get(key).(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+func (d *Data) GetString(key string) string {
+	switch v := d.Get(key).(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+// backendKey is the data key holding the server a service is bound to
+func backendKey(service string) string {
+	return "mcb-backend:" + service
+}
+
+// BindBackend sticks the session to the instance serverUID of service, so the
+// calls made with wrapper.StickyClient reach that instance. It's kept in the
+// data, call PushSession for the following requests to see it.
+func (d *Data) BindBackend(service, serverUID string) {
+	d.Set(backendKey(service), serverUID)
+}
+
+func (d *Data) UnbindBackend(service string) {
+	d.Remove(backendKey(service))
+}
+
+// Backend returns the instance of service the session is bound to
+func (d *Data) Backend(service string) string {
+	return d.GetString(backendKey(service))
+}
+
+// Marshal encodes the values the way they are sent in pb.Session.Data
+func (d *Data) Marshal() ([]byte, error) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return MarshalData(d.values)
+}
+
+// Unmarshal replaces the values with the ones encoded in b
+func (d *Data) Unmarshal(b []byte) error {
+	data, err := UnmarshalData(b)
+	if err != nil {
+		return err
+	}
+	d.mtx.Lock()
+	d.values = data
+	d.mtx.Unlock()
+	return nil
+}
+
+func MarshalData(data map[string]interface{}) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalData decodes pb.Session.Data, numbers are kept as json.Number
+func UnmarshalData(b []byte) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	if len(b) == 0 {
+		return data, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	return data, nil
+}
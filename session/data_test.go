@@ -0,0 +1,53 @@
+package session
+
+import "testing"
+
+func TestDataRoundTrip(t *testing.T) {
+	d := NewData()
+	d.Set("room", "r1")
+	d.Set("level", 12)
+	d.Set("gold", int64(1)<<40)
+	d.Set("ratio", 0.5)
+	d.Set("vip", true)
+
+	b, err := d.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := NewData()
+	if err := got.Unmarshal(b); err != nil {
+		t.Fatal(err)
+	}
+	if v := got.GetString("room"); v != "r1" {
+		t.Errorf("room = %v, want r1", v)
+	}
+	if v := got.GetInt("level"); v != 12 {
+		t.Errorf("level = %v, want 12", v)
+	}
+	if v := got.GetInt64("gold"); v != int64(1)<<40 {
+		t.Errorf("gold = %v, want %v", v, int64(1)<<40)
+	}
+	if v := got.GetFloat64("ratio"); v != 0.5 {
+		t.Errorf("ratio = %v, want 0.5", v)
+	}
+	if !got.GetBool("vip") {
+		t.Errorf("vip = false, want true")
+	}
+
+	got.Remove("room")
+	if got.HasKey("room") {
+		t.Errorf("room was not removed")
+	}
+}
+
+func TestUnmarshalEmptyData(t *testing.T) {
+	for _, b := range [][]byte{nil, []byte("null")} {
+		data, err := UnmarshalData(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data == nil {
+			t.Errorf("UnmarshalData(%q) returned a nil map", b)
+		}
+	}
+}
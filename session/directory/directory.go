@@ -0,0 +1,157 @@
+// Package directory keeps track of the frontend each uid is bound on, so a
+// user can be reached without an inbound request carrying mcb-session-fid.
+// The gate writes an entry on Bind and removes it when the session closes.
+package directory
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+var (
+	ErrNotFound = errors.New("uid is not bound on any frontend")
+	ErrNoSync   = errors.New("directory has no sync to lock a uid")
+	ErrNoExpiry = errors.New("directory entries never expire")
+
+	DefaultDirectory = NewDirectory()
+)
+
+// Entry is where a uid is bound
+type Entry struct {
+	Fid string `json:"fid"`
+	Sid int64  `json:"sid"`
+	// Gate is the service name of the gate, empty for the default one
+	Gate string `json:"gate,omitempty"`
+}
+
+type Directory interface {
+	Options() Options
+	// Bind records uid as bound to the session e.Sid on the frontend e.Fid
+	Bind(uid string, e *Entry) error
+	// Unbind removes the entry of uid if it still points to e.Fid and e.Sid,
+	// holding the lock of uid when there is a sync
+	Unbind(uid string, e *Entry) error
+	// Lookup returns where uid is bound or ErrNotFound
+	Lookup(uid string) (*Entry, error)
+	// Lock keeps the other frontends from binding uid until Unlock, it
+	// returns ErrNoSync when there is nothing to lock with
+	Lock(uid string) error
+	Unlock(uid string) error
+}
+
+type storeDirectory struct {
+	opts Options
+}
+
+func (d *storeDirectory) Options() Options {
+	return d.opts
+}
+
+func (d *storeDirectory) store() store.Store {
+	if d.opts.Store != nil {
+		return d.opts.Store
+	}
+	return store.DefaultStore
+}
+
+func (d *storeDirectory) Bind(uid string, e *Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return d.store().Write(&store.Record{
+		Key:    d.opts.Prefix + uid,
+		Value:  b,
+		Expiry: d.opts.Expiry,
+	})
+}
+
+// Unbind holds the lock of uid when there is a sync, so a bind on another
+// frontend can't be deleted between the lookup and the delete; without one
+// the entry is still compared before it's deleted
+func (d *storeDirectory) Unbind(uid string, e *Entry) (err error) {
+	if d.sync() != nil {
+		if err := d.Lock(uid); err != nil {
+			return err
+		}
+		defer func() {
+			if uerr := d.Unlock(uid); err == nil {
+				err = uerr
+			}
+		}()
+	}
+	return d.unbind(uid, e)
+}
+
+func (d *storeDirectory) unbind(uid string, e *Entry) error {
+	cur, err := d.Lookup(uid)
+	if err == ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// the uid has been bound again somewhere else in the meantime
+	if cur.Fid != e.Fid || cur.Sid != e.Sid {
+		return nil
+	}
+	err = d.store().Delete(d.opts.Prefix + uid)
+	if err == store.ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+func (d *storeDirectory) Lookup(uid string) (*Entry, error) {
+	recs, err := d.store().Read(d.opts.Prefix + uid)
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	e := new(Entry)
+	if err := json.Unmarshal(recs[0].Value, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (d *storeDirectory) sync() sync.Sync {
+	if d.opts.Sync != nil {
+		return d.opts.Sync
+	}
+	return sync.Default
+}
+
+func (d *storeDirectory) Lock(uid string) error {
+	s := d.sync()
+	if s == nil {
+		return ErrNoSync
+	}
+	ttl := d.opts.LockTTL
+	if ttl == 0 {
+		ttl = 10 * time.Second
+	}
+	return s.Lock(d.opts.Prefix+uid, sync.LockTTL(ttl))
+}
+
+func (d *storeDirectory) Unlock(uid string) error {
+	s := d.sync()
+	if s == nil {
+		return ErrNoSync
+	}
+	return s.Unlock(d.opts.Prefix + uid)
+}
+
+func NewDirectory(opts ...Option) Directory {
+	options := Options{
+		Prefix: "mcb-session-uid/",
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return &storeDirectory{opts: options}
+}
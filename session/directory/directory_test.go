@@ -0,0 +1,120 @@
+package directory
+
+import (
+	gosync "sync"
+	"testing"
+
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+// testSync holds the locks in process
+type testSync struct {
+	sync.Sync
+	mtx   gosync.Mutex
+	locks map[string]*gosync.Mutex
+}
+
+func (s *testSync) lock(id string) *gosync.Mutex {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.locks == nil {
+		s.locks = make(map[string]*gosync.Mutex)
+	}
+	if s.locks[id] == nil {
+		s.locks[id] = new(gosync.Mutex)
+	}
+	return s.locks[id]
+}
+
+func (s *testSync) Lock(id string, opts ...sync.LockOption) error {
+	s.lock(id).Lock()
+	return nil
+}
+
+func (s *testSync) Unlock(id string) error {
+	s.lock(id).Unlock()
+	return nil
+}
+
+func TestDirectory(t *testing.T) {
+	d := NewDirectory(Store(memory.NewStore()), Sync(new(testSync)))
+
+	if _, err := d.Lookup("u1"); err != ErrNotFound {
+		t.Fatalf("Lookup() error = %v, want %v", err, ErrNotFound)
+	}
+	if err := d.Bind("u1", &Entry{Fid: "gate-1", Sid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	// the user logs in again on another frontend before the old session closes
+	if err := d.Bind("u1", &Entry{Fid: "gate-2", Sid: 7, Gate: "ws-gate"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Unbind("u1", &Entry{Fid: "gate-1", Sid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	e, err := d.Lookup("u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Fid != "gate-2" || e.Sid != 7 || e.Gate != "ws-gate" {
+		t.Errorf("Lookup() = %+v, want ws-gate/gate-2/7", e)
+	}
+	if err := d.Unbind("u1", &Entry{Fid: "gate-2", Sid: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Lookup("u1"); err != ErrNotFound {
+		t.Errorf("Lookup() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestDirectoryNoSync(t *testing.T) {
+	d := NewDirectory(Store(memory.NewStore()))
+	if err := d.Lock("u1"); err != ErrNoSync {
+		t.Errorf("Lock() error = %v, want %v", err, ErrNoSync)
+	}
+	// a store only directory still clears the entries of closed sessions
+	if err := d.Bind("u1", &Entry{Fid: "gate-1", Sid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Unbind("u1", &Entry{Fid: "gate-2", Sid: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Lookup("u1"); err != nil {
+		t.Errorf("Lookup() error = %v after the unbind of another session", err)
+	}
+	if err := d.Unbind("u1", &Entry{Fid: "gate-1", Sid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Lookup("u1"); err != ErrNotFound {
+		t.Errorf("Lookup() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestDirectoryUnbindLocked(t *testing.T) {
+	s := new(testSync)
+	d := NewDirectory(Store(memory.NewStore()), Sync(s))
+	if err := d.Bind("u1", &Entry{Fid: "gate-1", Sid: 1}); err != nil {
+		t.Fatal(err)
+	}
+	// a bind on gate-2 holds the lock while the session of gate-1 closes
+	if err := d.Lock("u1"); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Unbind("u1", &Entry{Fid: "gate-1", Sid: 1})
+	}()
+	if err := d.Bind("u1", &Entry{Fid: "gate-2", Sid: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Unlock("u1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if e, err := d.Lookup("u1"); err != nil || e.Fid != "gate-2" {
+		t.Errorf("Lookup() = %+v, %v, want gate-2", e, err)
+	}
+}
@@ -0,0 +1,52 @@
+package directory
+
+import (
+	"time"
+
+	"github.com/micro/micro/v3/service/store"
+	"github.com/micro/micro/v3/service/sync"
+)
+
+type Options struct {
+	// Store holds the entries, store.DefaultStore when nil
+	Store store.Store
+	// Prefix is prepended to the uid to build the store key
+	Prefix string
+	// Expiry of an entry, 0 keeps it until the session closes. The gate
+	// should Bind the entry again within Expiry to keep it; LoginReject
+	// needs one, the uids of a gate dying without unbinding would be
+	// rejected forever otherwise
+	Expiry time.Duration
+	// Sync locks a uid while a bind checks where it's bound, sync.Default
+	// when nil; Lock returns ErrNoSync without both, Unbind doesn't lock
+	Sync sync.Sync
+	// LockTTL releases a lock whose holder died, 10s when 0
+	LockTTL time.Duration
+}
+type Option func(o *Options)
+
+func Store(s store.Store) Option {
+	return func(o *Options) {
+		o.Store = s
+	}
+}
+func Prefix(p string) Option {
+	return func(o *Options) {
+		o.Prefix = p
+	}
+}
+func Expiry(d time.Duration) Option {
+	return func(o *Options) {
+		o.Expiry = d
+	}
+}
+func Sync(s sync.Sync) Option {
+	return func(o *Options) {
+		o.Sync = s
+	}
+}
+func LockTTL(d time.Duration) Option {
+	return func(o *Options) {
+		o.LockTTL = d
+	}
+}
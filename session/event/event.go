@@ -0,0 +1,148 @@
+// Package event publishes and consumes the session lifecycle events of the
+// gate over the micro broker.
+//
+// Topics, each message body is the protobuf encoded event:
+//
+//	mcb.session.bind   pb.SessionBind   a uid was bound to a session
+//	mcb.session.close  pb.SessionClose  a session was closed by either side
+//	mcb.session.kick   pb.SessionKick   a session was kicked by a backend
+//
+// The gate publishes them, backends subscribe with a Subscriber:
+//
+//	sub := event.NewSubscriber(event.Queue("room"))
+//	sub.OnSessionClose(func(ev *pb.SessionClose) error {
+//		return rooms.Leave(ev.Uid)
+//	})
+package event
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/logger"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"sync"
+)
+
+const (
+	TopicBind  = "mcb.session.bind"
+	TopicClose = "mcb.session.close"
+	TopicKick  = "mcb.session.kick"
+)
+
+type Subscriber struct {
+	opts Options
+
+	mtx  sync.Mutex
+	subs []broker.Subscriber
+}
+
+func NewSubscriber(opts ...Option) *Subscriber {
+	var options Options
+	for _, o := range opts {
+		o(&options)
+	}
+	return &Subscriber{opts: options}
+}
+
+func (s *Subscriber) Options() Options {
+	return s.opts
+}
+
+func (s *Subscriber) OnBind(fn func(ev *pb.SessionBind) error) error {
+	return s.subscribe(TopicBind, func(b []byte) error {
+		ev := new(pb.SessionBind)
+		if err := proto.Unmarshal(b, ev); err != nil {
+			return err
+		}
+		return fn(ev)
+	})
+}
+
+func (s *Subscriber) OnSessionClose(fn func(ev *pb.SessionClose) error) error {
+	return s.subscribe(TopicClose, func(b []byte) error {
+		ev := new(pb.SessionClose)
+		if err := proto.Unmarshal(b, ev); err != nil {
+			return err
+		}
+		return fn(ev)
+	})
+}
+
+func (s *Subscriber) OnKick(fn func(ev *pb.SessionKick) error) error {
+	return s.subscribe(TopicKick, func(b []byte) error {
+		ev := new(pb.SessionKick)
+		if err := proto.Unmarshal(b, ev); err != nil {
+			return err
+		}
+		return fn(ev)
+	})
+}
+
+// Close unsubscribes every callback
+func (s *Subscriber) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var err error
+	for _, sub := range s.subs {
+		if e := sub.Unsubscribe(); e != nil {
+			err = e
+		}
+	}
+	s.subs = nil
+	return err
+}
+
+func (s *Subscriber) subscribe(topic string, fn func(b []byte) error) error {
+	var opts []broker.SubscribeOption
+	if len(s.opts.Queue) > 0 {
+		opts = append(opts, broker.Queue(s.opts.Queue))
+	}
+	sub, err := getBroker(s.opts).Subscribe(topic, func(m *broker.Message) error {
+		if err := fn(m.Body); err != nil {
+			logger.Errorf("session event %s, err:%v", topic, err)
+			return err
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	s.subs = append(s.subs, sub)
+	s.mtx.Unlock()
+	return nil
+}
+
+func PublishBind(ev *pb.SessionBind, opts ...Option) error {
+	return publish(TopicBind, ev, opts)
+}
+
+func PublishClose(ev *pb.SessionClose, opts ...Option) error {
+	return publish(TopicClose, ev, opts)
+}
+
+func PublishKick(ev *pb.SessionKick, opts ...Option) error {
+	return publish(TopicKick, ev, opts)
+}
+
+func publish(topic string, ev proto.Message, opts []Option) error {
+	var options Options
+	for _, o := range opts {
+		o(&options)
+	}
+	b, err := proto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return getBroker(options).Publish(topic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/protobuf"},
+		Body:   b,
+	})
+}
+
+func getBroker(opts Options) broker.Broker {
+	if opts.Broker != nil {
+		return opts.Broker
+	}
+	return broker.DefaultBroker
+}
@@ -0,0 +1,79 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/broker/memory"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+func newBroker(t *testing.T) broker.Broker {
+	b := memory.NewBroker()
+	if err := b.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEvents(t *testing.T) {
+	b := newBroker(t)
+	sub := NewSubscriber(Broker(b))
+
+	// the topics are the ones documented for the gate
+	topics := make(map[string]int)
+	for _, topic := range []string{"mcb.session.bind", "mcb.session.close", "mcb.session.kick"} {
+		topic := topic
+		s, err := b.Subscribe(topic, func(m *broker.Message) error {
+			topics[topic]++
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Unsubscribe()
+	}
+
+	var bind *pb.SessionBind
+	var closed *pb.SessionClose
+	var kick *pb.SessionKick
+	if err := sub.OnBind(func(ev *pb.SessionBind) error { bind = ev; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := sub.OnSessionClose(func(ev *pb.SessionClose) error { closed = ev; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := sub.OnKick(func(ev *pb.SessionKick) error { kick = ev; return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PublishBind(&pb.SessionBind{Uid: "u1", Fid: "gate-1", Sid: 1}, Broker(b)); err != nil {
+		t.Fatal(err)
+	}
+	if err := PublishClose(&pb.SessionClose{Uid: "u1", Fid: "gate-1", Sid: 1}, Broker(b)); err != nil {
+		t.Fatal(err)
+	}
+	err := PublishKick(&pb.SessionKick{Uid: "u1", Fid: "gate-1", Sid: 1, Reason: pb.KickReason_KickDuplicateLogin, Message: "bye"}, Broker(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, topic := range []string{TopicBind, TopicClose, TopicKick} {
+		if topics[topic] != 1 {
+			t.Errorf("%d events on %s, want 1", topics[topic], topic)
+		}
+	}
+	if bind == nil || bind.Uid != "u1" || bind.Fid != "gate-1" || bind.Sid != 1 {
+		t.Errorf("bind event = %v", bind)
+	}
+	if closed == nil || closed.Uid != "u1" || closed.Fid != "gate-1" || closed.Sid != 1 {
+		t.Errorf("close event = %v", closed)
+	}
+	if kick == nil || kick.Uid != "u1" || kick.Reason != pb.KickReason_KickDuplicateLogin || kick.Message != "bye" {
+		t.Errorf("kick event = %v", kick)
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -6,6 +6,8 @@ require (
 	github.com/golang/protobuf v1.4.3
 	github.com/micro/micro/v3 v3.3.0
 	github.com/oxtoacart/bpool v0.0.0-20190530202638-03653db5a59c
+	github.com/vmihailenco/msgpack/v5 v5.3.4
+	github.com/wolfplus2048/mcbeam-plugins/utils/wraperrors v0.0.0-20261018055913-83f751cfab6f
 	google.golang.org/protobuf v1.25.0
 )
 
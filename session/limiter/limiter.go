@@ -0,0 +1,107 @@
+// Package limiter rate limits the requests of the sessions and the pushes
+// to them with token buckets keyed by uid, or by frontend and sid until the
+// session is bound. Set the limiters with session.RequestLimiter and
+// session.PushLimiter; the redis plugin shares the buckets between the
+// instances of a service.
+package limiter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/wolfplus2048/mcbeam-plugins/utils/wraperrors"
+)
+
+// CodeRateLimited is the wraperrors code of ErrLimited
+const CodeRateLimited = 429
+
+// ErrLimited is returned when a bucket is empty, its detail is a wraperrors
+// message with CodeRateLimited
+var ErrLimited = errors.New("mcb.session.limiter", wraperrors.Errorf(CodeRateLimited, "rate limit exceeded"), 429)
+
+type Limiter interface {
+	Options() Options
+	// Allow takes a token from the bucket of key, false when it's empty
+	Allow(ctx context.Context, key string) (bool, error)
+	String() string
+}
+
+// Key returns the bucket key of a session, its uid once it's bound
+func Key(uid, fid string, sid int64) string {
+	if len(uid) > 0 {
+		return "uid:" + uid
+	}
+	return "sid:" + fid + "/" + strconv.FormatInt(sid, 10)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type memoryLimiter struct {
+	opts Options
+
+	mtx     sync.Mutex
+	buckets map[string]*bucket
+	sweep   time.Time
+}
+
+func (m *memoryLimiter) Options() Options {
+	return m.opts
+}
+
+func (m *memoryLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	now := time.Now()
+	burst := float64(m.opts.Burst)
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		m.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * m.opts.Rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+	m.gc(now)
+	if b.tokens < 1 {
+		return false, nil
+	}
+	b.tokens--
+	return true, nil
+}
+
+// gc drops the buckets which are full again once a minute, they are the
+// same as new ones
+func (m *memoryLimiter) gc(now time.Time) {
+	if now.Sub(m.sweep) < time.Minute {
+		return
+	}
+	m.sweep = now
+	burst := float64(m.opts.Burst)
+	for k, b := range m.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*m.opts.Rate >= burst {
+			delete(m.buckets, k)
+		}
+	}
+}
+
+func (m *memoryLimiter) String() string {
+	return "memory"
+}
+
+// NewLimiter returns a limiter keeping the buckets in memory
+func NewLimiter(opts ...Option) Limiter {
+	return &memoryLimiter{
+		opts:    NewOptions(opts...),
+		buckets: make(map[string]*bucket),
+		sweep:   time.Now(),
+	}
+}
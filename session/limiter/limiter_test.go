@@ -0,0 +1,40 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/errors"
+	"github.com/wolfplus2048/mcbeam-plugins/utils/wraperrors"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	l := NewLimiter(Rate(100), Burst(3))
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if ok, err := l.Allow(ctx, "uid:u1"); err != nil || !ok {
+			t.Fatalf("Allow() #%d = %v, %v", i, ok, err)
+		}
+	}
+	if ok, _ := l.Allow(ctx, "uid:u1"); ok {
+		t.Fatal("Allow() = true with an empty bucket")
+	}
+	if ok, _ := l.Allow(ctx, "uid:u2"); !ok {
+		t.Fatal("Allow() = false for another key")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if ok, _ := l.Allow(ctx, "uid:u1"); !ok {
+		t.Fatal("Allow() = false after the bucket refilled")
+	}
+}
+
+func TestErrLimited(t *testing.T) {
+	e, err := wraperrors.Parse(errors.Parse(ErrLimited.Error()).Detail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Code != CodeRateLimited {
+		t.Errorf("code = %d, want %d", e.Code, CodeRateLimited)
+	}
+}
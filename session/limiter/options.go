@@ -0,0 +1,45 @@
+package limiter
+
+import "context"
+
+type Options struct {
+	// Rate is the number of tokens added to a bucket per second
+	Rate float64
+	// Burst is the size of a bucket
+	Burst int
+	// Prefix is prepended to the keys by the limiters sharing a store
+	Prefix string
+	// Context holds the options of the implementations
+	Context context.Context
+}
+type Option func(o *Options)
+
+// NewOptions applies opts to the defaults: 10 tokens per second, 20 at once
+func NewOptions(opts ...Option) Options {
+	options := Options{
+		Rate:    10,
+		Burst:   20,
+		Prefix:  "mcb-limiter/",
+		Context: context.Background(),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+func Rate(r float64) Option {
+	return func(o *Options) {
+		o.Rate = r
+	}
+}
+func Burst(n int) Option {
+	return func(o *Options) {
+		o.Burst = n
+	}
+}
+func Prefix(p string) Option {
+	return func(o *Options) {
+		o.Prefix = p
+	}
+}
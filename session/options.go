@@ -1,9 +1,51 @@
 package session
 
+import (
+	"context"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/directory"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
 type Options struct {
 	Sid int64
 	Fid string
 	Uid string
+	// Context is used by the calls that don't take a context
+	Context context.Context
+	// Codec encodes the pushes, codec.DefaultMarshaler when nil
+	Codec codec.Marshaler
+	// Routes compresses the push routes, route.DefaultDictionary when nil
+	Routes *route.Dictionary
+	// GateName is the service name of the gate, "gate" when empty
+	GateName string
+	// Client calls the gate, client.DefaultClient when nil
+	Client client.Client
+	// CallOptions are added to every call to the gate, e.g. retries and
+	// timeouts
+	CallOptions []client.CallOption
+	// Gate replaces the client of the gate service, e.g. with the fake of
+	// session/sessiontest, GateName and Client are then unused
+	Gate pb.McbGateService
+	// DuplicateLogin is what Bind does when the uid is bound on another
+	// session, LoginAllowMulti by default
+	DuplicateLogin LoginPolicy
+	// Directory is where Bind looks for and records the session of a uid when
+	// DuplicateLogin isn't LoginAllowMulti, directory.DefaultDirectory when nil;
+	// it needs a Sync to lock the uid
+	Directory directory.Directory
+	// Pusher sends the pushes instead of a unary call to the gate, e.g. over
+	// the stream of client.NewStreamPusher
+	Pusher Pusher
+	// RequestLimiter limits the requests of the session in
+	// wrapper.SessionHandler, no limit when nil
+	RequestLimiter limiter.Limiter
+	// PushLimiter limits the pushes to the session, no limit when nil
+	PushLimiter limiter.Limiter
 }
 type Option func(o *Options)
 
@@ -21,4 +63,108 @@ func Uid(uid string) Option {
 	return func(o *Options) {
 		o.Uid = uid
 	}
-}
\ No newline at end of file
+}
+
+// Context sets the context used by Bind, Kick, PushSession and Push
+func Context(ctx context.Context) Option {
+	return func(o *Options) {
+		o.Context = ctx
+	}
+}
+
+// Codec sets the marshaler used to encode the pushes
+func Codec(c codec.Marshaler) Option {
+	return func(o *Options) {
+		o.Codec = c
+	}
+}
+
+// Routes sets the dictionary used to compress the push routes
+func Routes(d *route.Dictionary) Option {
+	return func(o *Options) {
+		o.Routes = d
+	}
+}
+
+// GateName sets the service name of the gate
+func GateName(name string) Option {
+	return func(o *Options) {
+		o.GateName = name
+	}
+}
+
+// Client sets the client used to call the gate
+func Client(c client.Client) Option {
+	return func(o *Options) {
+		o.Client = c
+	}
+}
+
+// CallOptions adds default options to every call to the gate
+func CallOptions(opts ...client.CallOption) Option {
+	return func(o *Options) {
+		o.CallOptions = append(o.CallOptions, opts...)
+	}
+}
+
+// Gate sets the client used to reach the gate service
+func Gate(g pb.McbGateService) Option {
+	return func(o *Options) {
+		o.Gate = g
+	}
+}
+
+// DuplicateLogin sets what Bind does when the uid is bound on another session
+func DuplicateLogin(p LoginPolicy) Option {
+	return func(o *Options) {
+		o.DuplicateLogin = p
+	}
+}
+
+// Directory sets the directory checked by Bind
+func Directory(d directory.Directory) Option {
+	return func(o *Options) {
+		o.Directory = d
+	}
+}
+
+// WithPusher sets the pusher used instead of a unary call to the gate
+func WithPusher(p Pusher) Option {
+	return func(o *Options) {
+		o.Pusher = p
+	}
+}
+
+// RequestLimiter sets the limiter of the requests of the session
+func RequestLimiter(l limiter.Limiter) Option {
+	return func(o *Options) {
+		o.RequestLimiter = l
+	}
+}
+
+// PushLimiter sets the limiter of the pushes to the session
+func PushLimiter(l limiter.Limiter) Option {
+	return func(o *Options) {
+		o.PushLimiter = l
+	}
+}
+
+// KickOptions tell the client why it is kicked
+type KickOptions struct {
+	Reason  pb.KickReason
+	Message string
+}
+type KickOption func(o *KickOptions)
+
+func KickReason(r pb.KickReason) KickOption {
+	return func(o *KickOptions) {
+		o.Reason = r
+	}
+}
+
+// KickMessage sets a text shown to the user, e.g. the end of a maintenance
+func KickMessage(msg string) KickOption {
+	return func(o *KickOptions) {
+		o.Message = msg
+	}
+}
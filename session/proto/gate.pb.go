@@ -77,6 +77,63 @@ func (MsgType) EnumDescriptor() ([]byte, []int) {
 	return file_session_proto_gate_proto_rawDescGZIP(), []int{0}
 }
 
+// KickReason tells the client why it was kicked, applications may use their
+// own values above 100
+type KickReason int32
+
+const (
+	KickReason_KickUnknown        KickReason = 0
+	KickReason_KickDuplicateLogin KickReason = 1
+	KickReason_KickBanned         KickReason = 2
+	KickReason_KickMaintenance    KickReason = 3
+	KickReason_KickIdle           KickReason = 4
+)
+
+// Enum value maps for KickReason.
+var (
+	KickReason_name = map[int32]string{
+		0: "KickUnknown",
+		1: "KickDuplicateLogin",
+		2: "KickBanned",
+		3: "KickMaintenance",
+		4: "KickIdle",
+	}
+	KickReason_value = map[string]int32{
+		"KickUnknown":        0,
+		"KickDuplicateLogin": 1,
+		"KickBanned":         2,
+		"KickMaintenance":    3,
+		"KickIdle":           4,
+	}
+)
+
+func (x KickReason) Enum() *KickReason {
+	p := new(KickReason)
+	*p = x
+	return p
+}
+
+func (x KickReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KickReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_session_proto_gate_proto_enumTypes[1].Descriptor()
+}
+
+func (KickReason) Type() protoreflect.EnumType {
+	return &file_session_proto_gate_proto_enumTypes[1]
+}
+
+func (x KickReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KickReason.Descriptor instead.
+func (KickReason) EnumDescriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{1}
+}
+
 type RPCType int32
 
 const (
@@ -107,11 +164,11 @@ func (x RPCType) String() string {
 }
 
 func (RPCType) Descriptor() protoreflect.EnumDescriptor {
-	return file_session_proto_gate_proto_enumTypes[1].Descriptor()
+	return file_session_proto_gate_proto_enumTypes[2].Descriptor()
 }
 
 func (RPCType) Type() protoreflect.EnumType {
-	return &file_session_proto_gate_proto_enumTypes[1]
+	return &file_session_proto_gate_proto_enumTypes[2]
 }
 
 func (x RPCType) Number() protoreflect.EnumNumber {
@@ -120,7 +177,7 @@ func (x RPCType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RPCType.Descriptor instead.
 func (RPCType) EnumDescriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{1}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{2}
 }
 
 type Error struct {
@@ -257,18 +314,85 @@ func (x *Session) GetData() []byte {
 	return nil
 }
 
+// SessionBind, SessionClose and SessionKick are the lifecycle events the
+// gate publishes, see the session/event package for the topics
+type SessionBind struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Fid string `protobuf:"bytes,2,opt,name=fid,proto3" json:"fid,omitempty"`
+	Sid int64  `protobuf:"varint,3,opt,name=sid,proto3" json:"sid,omitempty"`
+}
+
+func (x *SessionBind) Reset() {
+	*x = SessionBind{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_session_proto_gate_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionBind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionBind) ProtoMessage() {}
+
+func (x *SessionBind) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_gate_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionBind.ProtoReflect.Descriptor instead.
+func (*SessionBind) Descriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SessionBind) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *SessionBind) GetFid() string {
+	if x != nil {
+		return x.Fid
+	}
+	return ""
+}
+
+func (x *SessionBind) GetSid() int64 {
+	if x != nil {
+		return x.Sid
+	}
+	return 0
+}
+
 type SessionClose struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Fid string `protobuf:"bytes,2,opt,name=fid,proto3" json:"fid,omitempty"`
+	Sid int64  `protobuf:"varint,3,opt,name=sid,proto3" json:"sid,omitempty"`
 }
 
 func (x *SessionClose) Reset() {
 	*x = SessionClose{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[2]
+		mi := &file_session_proto_gate_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -281,7 +405,7 @@ func (x *SessionClose) String() string {
 func (*SessionClose) ProtoMessage() {}
 
 func (x *SessionClose) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[2]
+	mi := &file_session_proto_gate_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +418,7 @@ func (x *SessionClose) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SessionClose.ProtoReflect.Descriptor instead.
 func (*SessionClose) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{2}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SessionClose) GetUid() string {
@@ -304,6 +428,99 @@ func (x *SessionClose) GetUid() string {
 	return ""
 }
 
+func (x *SessionClose) GetFid() string {
+	if x != nil {
+		return x.Fid
+	}
+	return ""
+}
+
+func (x *SessionClose) GetSid() int64 {
+	if x != nil {
+		return x.Sid
+	}
+	return 0
+}
+
+type SessionKick struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid     string     `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Fid     string     `protobuf:"bytes,2,opt,name=fid,proto3" json:"fid,omitempty"`
+	Sid     int64      `protobuf:"varint,3,opt,name=sid,proto3" json:"sid,omitempty"`
+	Reason  KickReason `protobuf:"varint,4,opt,name=reason,proto3,enum=gate.KickReason" json:"reason,omitempty"`
+	Message string     `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SessionKick) Reset() {
+	*x = SessionKick{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_session_proto_gate_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionKick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionKick) ProtoMessage() {}
+
+func (x *SessionKick) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_gate_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionKick.ProtoReflect.Descriptor instead.
+func (*SessionKick) Descriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionKick) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *SessionKick) GetFid() string {
+	if x != nil {
+		return x.Fid
+	}
+	return ""
+}
+
+func (x *SessionKick) GetSid() int64 {
+	if x != nil {
+		return x.Sid
+	}
+	return 0
+}
+
+func (x *SessionKick) GetReason() KickReason {
+	if x != nil {
+		return x.Reason
+	}
+	return KickReason_KickUnknown
+}
+
+func (x *SessionKick) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type Msg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -314,12 +531,14 @@ type Msg struct {
 	Data  []byte  `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	Reply string  `protobuf:"bytes,4,opt,name=reply,proto3" json:"reply,omitempty"`
 	Type  MsgType `protobuf:"varint,5,opt,name=type,proto3,enum=gate.MsgType" json:"type,omitempty"`
+	// routeCode replaces route when it's compressed, see session/route
+	RouteCode uint32 `protobuf:"varint,6,opt,name=routeCode,proto3" json:"routeCode,omitempty"`
 }
 
 func (x *Msg) Reset() {
 	*x = Msg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[3]
+		mi := &file_session_proto_gate_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -332,7 +551,7 @@ func (x *Msg) String() string {
 func (*Msg) ProtoMessage() {}
 
 func (x *Msg) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[3]
+	mi := &file_session_proto_gate_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,7 +564,7 @@ func (x *Msg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Msg.ProtoReflect.Descriptor instead.
 func (*Msg) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{3}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Msg) GetId() uint64 {
@@ -383,18 +602,29 @@ func (x *Msg) GetType() MsgType {
 	return MsgType_MsgRequest
 }
 
+func (x *Msg) GetRouteCode() uint32 {
+	if x != nil {
+		return x.RouteCode
+	}
+	return 0
+}
+
+// KickMsg closes the session of userId, the gate sends reason and message to
+// the client before closing
 type KickMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	UserId  string     `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Reason  KickReason `protobuf:"varint,2,opt,name=reason,proto3,enum=gate.KickReason" json:"reason,omitempty"`
+	Message string     `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
 }
 
 func (x *KickMsg) Reset() {
 	*x = KickMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[4]
+		mi := &file_session_proto_gate_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -407,7 +637,7 @@ func (x *KickMsg) String() string {
 func (*KickMsg) ProtoMessage() {}
 
 func (x *KickMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[4]
+	mi := &file_session_proto_gate_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +650,7 @@ func (x *KickMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickMsg.ProtoReflect.Descriptor instead.
 func (*KickMsg) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{4}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *KickMsg) GetUserId() string {
@@ -430,6 +660,21 @@ func (x *KickMsg) GetUserId() string {
 	return ""
 }
 
+func (x *KickMsg) GetReason() KickReason {
+	if x != nil {
+		return x.Reason
+	}
+	return KickReason_KickUnknown
+}
+
+func (x *KickMsg) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// KickAnswer tells whether userId had a session on the gate
 type KickAnswer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -441,7 +686,7 @@ type KickAnswer struct {
 func (x *KickAnswer) Reset() {
 	*x = KickAnswer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[5]
+		mi := &file_session_proto_gate_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -454,7 +699,7 @@ func (x *KickAnswer) String() string {
 func (*KickAnswer) ProtoMessage() {}
 
 func (x *KickAnswer) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[5]
+	mi := &file_session_proto_gate_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -467,7 +712,7 @@ func (x *KickAnswer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickAnswer.ProtoReflect.Descriptor instead.
 func (*KickAnswer) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{5}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *KickAnswer) GetKicked() bool {
@@ -485,12 +730,17 @@ type PushMsg struct {
 	Route string `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
 	Uid   string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
 	Data  []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// routeCode replaces route when it's compressed, see session/route
+	RouteCode uint32 `protobuf:"varint,4,opt,name=routeCode,proto3" json:"routeCode,omitempty"`
+	// seq numbers the pushes of uid from 1 when they are ordered, see
+	// client.NewOrderedPusher, 0 otherwise
+	Seq uint64 `protobuf:"varint,5,opt,name=seq,proto3" json:"seq,omitempty"`
 }
 
 func (x *PushMsg) Reset() {
 	*x = PushMsg{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[6]
+		mi := &file_session_proto_gate_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -503,7 +753,7 @@ func (x *PushMsg) String() string {
 func (*PushMsg) ProtoMessage() {}
 
 func (x *PushMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[6]
+	mi := &file_session_proto_gate_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -516,7 +766,7 @@ func (x *PushMsg) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PushMsg.ProtoReflect.Descriptor instead.
 func (*PushMsg) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{6}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PushMsg) GetRoute() string {
@@ -540,6 +790,197 @@ func (x *PushMsg) GetData() []byte {
 	return nil
 }
 
+func (x *PushMsg) GetRouteCode() uint32 {
+	if x != nil {
+		return x.RouteCode
+	}
+	return 0
+}
+
+func (x *PushMsg) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+// PushBatchMsg pushes the same message to many users of one frontend,
+// when all is set it goes to every bound session and uids is ignored
+type PushBatchMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Route     string   `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	Uids      []string `protobuf:"bytes,2,rep,name=uids,proto3" json:"uids,omitempty"`
+	Data      []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	All       bool     `protobuf:"varint,4,opt,name=all,proto3" json:"all,omitempty"`
+	RouteCode uint32   `protobuf:"varint,5,opt,name=routeCode,proto3" json:"routeCode,omitempty"`
+}
+
+func (x *PushBatchMsg) Reset() {
+	*x = PushBatchMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_session_proto_gate_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBatchMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBatchMsg) ProtoMessage() {}
+
+func (x *PushBatchMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_gate_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBatchMsg.ProtoReflect.Descriptor instead.
+func (*PushBatchMsg) Descriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PushBatchMsg) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *PushBatchMsg) GetUids() []string {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+func (x *PushBatchMsg) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PushBatchMsg) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *PushBatchMsg) GetRouteCode() uint32 {
+	if x != nil {
+		return x.RouteCode
+	}
+	return 0
+}
+
+// PushBatchAnswer reports the uids that were not online
+type PushBatchAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FailedUids []string `protobuf:"bytes,1,rep,name=failedUids,proto3" json:"failedUids,omitempty"`
+}
+
+func (x *PushBatchAnswer) Reset() {
+	*x = PushBatchAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_session_proto_gate_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushBatchAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushBatchAnswer) ProtoMessage() {}
+
+func (x *PushBatchAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_gate_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushBatchAnswer.ProtoReflect.Descriptor instead.
+func (*PushBatchAnswer) Descriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PushBatchAnswer) GetFailedUids() []string {
+	if x != nil {
+		return x.FailedUids
+	}
+	return nil
+}
+
+// PushStreamAnswer is sent when the backend closes the stream
+type PushStreamAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pushed uint64 `protobuf:"varint,1,opt,name=pushed,proto3" json:"pushed,omitempty"`
+}
+
+func (x *PushStreamAnswer) Reset() {
+	*x = PushStreamAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_session_proto_gate_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushStreamAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushStreamAnswer) ProtoMessage() {}
+
+func (x *PushStreamAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_gate_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushStreamAnswer.ProtoReflect.Descriptor instead.
+func (*PushStreamAnswer) Descriptor() ([]byte, []int) {
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PushStreamAnswer) GetPushed() uint64 {
+	if x != nil {
+		return x.Pushed
+	}
+	return 0
+}
+
 type Request struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -555,7 +996,7 @@ type Request struct {
 func (x *Request) Reset() {
 	*x = Request{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[7]
+		mi := &file_session_proto_gate_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -568,7 +1009,7 @@ func (x *Request) String() string {
 func (*Request) ProtoMessage() {}
 
 func (x *Request) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[7]
+	mi := &file_session_proto_gate_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +1022,7 @@ func (x *Request) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Request.ProtoReflect.Descriptor instead.
 func (*Request) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{7}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Request) GetType() RPCType {
@@ -631,7 +1072,7 @@ type Response struct {
 func (x *Response) Reset() {
 	*x = Response{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_session_proto_gate_proto_msgTypes[8]
+		mi := &file_session_proto_gate_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -644,7 +1085,7 @@ func (x *Response) String() string {
 func (*Response) ProtoMessage() {}
 
 func (x *Response) ProtoReflect() protoreflect.Message {
-	mi := &file_session_proto_gate_proto_msgTypes[8]
+	mi := &file_session_proto_gate_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +1098,7 @@ func (x *Response) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Response.ProtoReflect.Descriptor instead.
 func (*Response) Descriptor() ([]byte, []int) {
-	return file_session_proto_gate_proto_rawDescGZIP(), []int{8}
+	return file_session_proto_gate_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Response) GetData() []byte {
@@ -688,62 +1129,114 @@ var file_session_proto_gate_proto_rawDesc = []byte{
 	0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x20,
-	0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64,
-	0x22, 0x78, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x73, 0x67,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x21, 0x0a, 0x07, 0x4b, 0x69,
-	0x63, 0x6b, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x24, 0x0a,
-	0x0a, 0x4b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6b,
-	0x69, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6b, 0x69, 0x63,
-	0x6b, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x07, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x14,
-	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72,
-	0x6f, 0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xae, 0x01, 0x0a, 0x07, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x50, 0x43, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x09, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12,
-	0x1e, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x49, 0x44, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x49, 0x44, 0x12,
-	0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x41, 0x0a, 0x08, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21, 0x0a, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0x46,
-	0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x73, 0x67,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x73, 0x67,
-	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x73, 0x67, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x73, 0x67,
-	0x50, 0x75, 0x73, 0x68, 0x10, 0x03, 0x2a, 0x1c, 0x0a, 0x07, 0x52, 0x50, 0x43, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x79, 0x73, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x73,
-	0x65, 0x72, 0x10, 0x01, 0x32, 0x31, 0x0a, 0x06, 0x4d, 0x63, 0x62, 0x41, 0x70, 0x70, 0x12, 0x27,
-	0x0a, 0x04, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xb6, 0x01, 0x0a, 0x07, 0x4d, 0x63, 0x62, 0x47,
-	0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x0d, 0x2e, 0x67, 0x61,
-	0x74, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0b,
-	0x50, 0x75, 0x73, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x2e, 0x67, 0x61,
-	0x74, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x04,
-	0x42, 0x69, 0x6e, 0x64, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x29, 0x0a, 0x04, 0x4b, 0x69, 0x63, 0x6b, 0x12, 0x0d, 0x2e,
-	0x67, 0x61, 0x74, 0x65, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x73, 0x67, 0x1a, 0x10, 0x2e, 0x67,
-	0x61, 0x74, 0x65, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x00,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x43,
+	0x0a, 0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x66, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x73, 0x69, 0x64, 0x22, 0x44, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6c,
+	0x6f, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x69, 0x64, 0x22, 0x87, 0x01, 0x0a, 0x0b, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x69, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x66,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12,
+	0x28, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x03, 0x4d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x65, 0x0a, 0x07,
+	0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x73, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x28, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x10, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x24, 0x0a, 0x0a, 0x4b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x6b, 0x69, 0x63, 0x6b, 0x65, 0x64, 0x22, 0x75, 0x0a, 0x07, 0x50, 0x75, 0x73,
+	0x68, 0x4d, 0x73, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71,
+	0x22, 0x7c, 0x0a, 0x0c, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x73, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x69, 0x64, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x69, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c,
+	0x12, 0x1c, 0x0a, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x31,
+	0x0a, 0x0f, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x55, 0x69, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x55, 0x69, 0x64,
+	0x73, 0x22, 0x2a, 0x0a, 0x10, 0x50, 0x75, 0x73, 0x68, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x22, 0xae, 0x01,
+	0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52,
+	0x50, 0x43, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x27, 0x0a, 0x07,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d,
+	0x73, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x49, 0x44,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x72, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64,
+	0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x41,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x21,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x2a, 0x46, 0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x0a,
+	0x4d, 0x73, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x4d, 0x73, 0x67, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x4d,
+	0x73, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
+	0x4d, 0x73, 0x67, 0x50, 0x75, 0x73, 0x68, 0x10, 0x03, 0x2a, 0x68, 0x0a, 0x0a, 0x4b, 0x69, 0x63,
+	0x6b, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0f, 0x0a, 0x0b, 0x4b, 0x69, 0x63, 0x6b, 0x55,
+	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4b, 0x69, 0x63, 0x6b,
+	0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x10, 0x01,
+	0x12, 0x0e, 0x0a, 0x0a, 0x4b, 0x69, 0x63, 0x6b, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x10, 0x02,
+	0x12, 0x13, 0x0a, 0x0f, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x4b, 0x69, 0x63, 0x6b, 0x49, 0x64, 0x6c,
+	0x65, 0x10, 0x04, 0x2a, 0x1c, 0x0a, 0x07, 0x52, 0x50, 0x43, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07,
+	0x0a, 0x03, 0x53, 0x79, 0x73, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x10,
+	0x01, 0x32, 0x31, 0x0a, 0x06, 0x4d, 0x63, 0x62, 0x41, 0x70, 0x70, 0x12, 0x27, 0x0a, 0x04, 0x43,
+	0x61, 0x6c, 0x6c, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x32, 0xa9, 0x02, 0x0a, 0x07, 0x4d, 0x63, 0x62, 0x47, 0x61, 0x74, 0x65,
+	0x12, 0x27, 0x0a, 0x04, 0x50, 0x75, 0x73, 0x68, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0b, 0x50, 0x75, 0x73,
+	0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x27, 0x0a, 0x04, 0x42, 0x69, 0x6e,
+	0x64, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x1a, 0x0e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x29, 0x0a, 0x04, 0x4b, 0x69, 0x63, 0x6b, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x73, 0x67, 0x1a, 0x10, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x00, 0x12, 0x38, 0x0a,
+	0x09, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x73, 0x67, 0x1a, 0x15,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x0d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x50, 0x75, 0x73,
+	0x68, 0x4d, 0x73, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x2e, 0x50, 0x75, 0x73, 0x68,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x00, 0x28, 0x01,
 	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
@@ -759,42 +1252,54 @@ func file_session_proto_gate_proto_rawDescGZIP() []byte {
 	return file_session_proto_gate_proto_rawDescData
 }
 
-var file_session_proto_gate_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_session_proto_gate_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_session_proto_gate_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_session_proto_gate_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_session_proto_gate_proto_goTypes = []interface{}{
-	(MsgType)(0),         // 0: gate.MsgType
-	(RPCType)(0),         // 1: gate.RPCType
-	(*Error)(nil),        // 2: gate.Error
-	(*Session)(nil),      // 3: gate.Session
-	(*SessionClose)(nil), // 4: gate.SessionClose
-	(*Msg)(nil),          // 5: gate.Msg
-	(*KickMsg)(nil),      // 6: gate.KickMsg
-	(*KickAnswer)(nil),   // 7: gate.KickAnswer
-	(*PushMsg)(nil),      // 8: gate.PushMsg
-	(*Request)(nil),      // 9: gate.Request
-	(*Response)(nil),     // 10: gate.Response
+	(MsgType)(0),             // 0: gate.MsgType
+	(KickReason)(0),          // 1: gate.KickReason
+	(RPCType)(0),             // 2: gate.RPCType
+	(*Error)(nil),            // 3: gate.Error
+	(*Session)(nil),          // 4: gate.Session
+	(*SessionBind)(nil),      // 5: gate.SessionBind
+	(*SessionClose)(nil),     // 6: gate.SessionClose
+	(*SessionKick)(nil),      // 7: gate.SessionKick
+	(*Msg)(nil),              // 8: gate.Msg
+	(*KickMsg)(nil),          // 9: gate.KickMsg
+	(*KickAnswer)(nil),       // 10: gate.KickAnswer
+	(*PushMsg)(nil),          // 11: gate.PushMsg
+	(*PushBatchMsg)(nil),     // 12: gate.PushBatchMsg
+	(*PushBatchAnswer)(nil),  // 13: gate.PushBatchAnswer
+	(*PushStreamAnswer)(nil), // 14: gate.PushStreamAnswer
+	(*Request)(nil),          // 15: gate.Request
+	(*Response)(nil),         // 16: gate.Response
 }
 var file_session_proto_gate_proto_depIdxs = []int32{
-	0,  // 0: gate.Msg.type:type_name -> gate.MsgType
-	1,  // 1: gate.Request.type:type_name -> gate.RPCType
-	3,  // 2: gate.Request.session:type_name -> gate.Session
-	5,  // 3: gate.Request.msg:type_name -> gate.Msg
-	2,  // 4: gate.Response.error:type_name -> gate.Error
-	9,  // 5: gate.McbApp.Call:input_type -> gate.Request
-	8,  // 6: gate.McbGate.Push:input_type -> gate.PushMsg
-	3,  // 7: gate.McbGate.PushSession:input_type -> gate.Session
-	3,  // 8: gate.McbGate.Bind:input_type -> gate.Session
-	6,  // 9: gate.McbGate.Kick:input_type -> gate.KickMsg
-	10, // 10: gate.McbApp.Call:output_type -> gate.Response
-	10, // 11: gate.McbGate.Push:output_type -> gate.Response
-	10, // 12: gate.McbGate.PushSession:output_type -> gate.Response
-	10, // 13: gate.McbGate.Bind:output_type -> gate.Response
-	7,  // 14: gate.McbGate.Kick:output_type -> gate.KickAnswer
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	1,  // 0: gate.SessionKick.reason:type_name -> gate.KickReason
+	0,  // 1: gate.Msg.type:type_name -> gate.MsgType
+	1,  // 2: gate.KickMsg.reason:type_name -> gate.KickReason
+	2,  // 3: gate.Request.type:type_name -> gate.RPCType
+	4,  // 4: gate.Request.session:type_name -> gate.Session
+	8,  // 5: gate.Request.msg:type_name -> gate.Msg
+	3,  // 6: gate.Response.error:type_name -> gate.Error
+	15, // 7: gate.McbApp.Call:input_type -> gate.Request
+	11, // 8: gate.McbGate.Push:input_type -> gate.PushMsg
+	4,  // 9: gate.McbGate.PushSession:input_type -> gate.Session
+	4,  // 10: gate.McbGate.Bind:input_type -> gate.Session
+	9,  // 11: gate.McbGate.Kick:input_type -> gate.KickMsg
+	12, // 12: gate.McbGate.PushBatch:input_type -> gate.PushBatchMsg
+	11, // 13: gate.McbGate.PushStream:input_type -> gate.PushMsg
+	16, // 14: gate.McbApp.Call:output_type -> gate.Response
+	16, // 15: gate.McbGate.Push:output_type -> gate.Response
+	16, // 16: gate.McbGate.PushSession:output_type -> gate.Response
+	16, // 17: gate.McbGate.Bind:output_type -> gate.Response
+	10, // 18: gate.McbGate.Kick:output_type -> gate.KickAnswer
+	13, // 19: gate.McbGate.PushBatch:output_type -> gate.PushBatchAnswer
+	14, // 20: gate.McbGate.PushStream:output_type -> gate.PushStreamAnswer
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_session_proto_gate_proto_init() }
@@ -828,7 +1333,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SessionClose); i {
+			switch v := v.(*SessionBind); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -840,7 +1345,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Msg); i {
+			switch v := v.(*SessionClose); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -852,7 +1357,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KickMsg); i {
+			switch v := v.(*SessionKick); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -864,7 +1369,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KickAnswer); i {
+			switch v := v.(*Msg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -876,7 +1381,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PushMsg); i {
+			switch v := v.(*KickMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -888,7 +1393,7 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Request); i {
+			switch v := v.(*KickAnswer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -900,6 +1405,66 @@ func file_session_proto_gate_proto_init() {
 			}
 		}
 		file_session_proto_gate_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_session_proto_gate_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBatchMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_session_proto_gate_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushBatchAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_session_proto_gate_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushStreamAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_session_proto_gate_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_session_proto_gate_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Response); i {
 			case 0:
 				return &v.state
@@ -917,8 +1482,8 @@ func file_session_proto_gate_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_session_proto_gate_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   9,
+			NumEnums:      3,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -105,6 +105,10 @@ type McbGateService interface {
 	PushSession(ctx context.Context, in *Session, opts ...client.CallOption) (*Response, error)
 	Bind(ctx context.Context, in *Session, opts ...client.CallOption) (*Response, error)
 	Kick(ctx context.Context, in *KickMsg, opts ...client.CallOption) (*KickAnswer, error)
+	PushBatch(ctx context.Context, in *PushBatchMsg, opts ...client.CallOption) (*PushBatchAnswer, error)
+	// PushStream carries the pushes of a backend to every session of the
+	// frontend, see client.NewStreamPusher
+	PushStream(ctx context.Context, opts ...client.CallOption) (McbGate_PushStreamService, error)
 }
 
 type mcbGateService struct {
@@ -159,6 +163,62 @@ func (c *mcbGateService) Kick(ctx context.Context, in *KickMsg, opts ...client.C
 	return out, nil
 }
 
+func (c *mcbGateService) PushBatch(ctx context.Context, in *PushBatchMsg, opts ...client.CallOption) (*PushBatchAnswer, error) {
+	req := c.c.NewRequest(c.name, "McbGate.PushBatch", in)
+	out := new(PushBatchAnswer)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mcbGateService) PushStream(ctx context.Context, opts ...client.CallOption) (McbGate_PushStreamService, error) {
+	req := c.c.NewRequest(c.name, "McbGate.PushStream", &PushMsg{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &mcbGateServicePushStream{stream}, nil
+}
+
+type McbGate_PushStreamService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseAndRecv() (*PushStreamAnswer, error)
+	Send(*PushMsg) error
+}
+
+type mcbGateServicePushStream struct {
+	stream client.Stream
+}
+
+func (x *mcbGateServicePushStream) CloseAndRecv() (*PushStreamAnswer, error) {
+	if err := x.stream.Close(); err != nil {
+		return nil, err
+	}
+	r := new(PushStreamAnswer)
+	err := x.RecvMsg(r)
+	return r, err
+}
+
+func (x *mcbGateServicePushStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *mcbGateServicePushStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mcbGateServicePushStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mcbGateServicePushStream) Send(m *PushMsg) error {
+	return x.stream.Send(m)
+}
+
 // Server API for McbGate service
 
 type McbGateHandler interface {
@@ -166,6 +226,10 @@ type McbGateHandler interface {
 	PushSession(context.Context, *Session, *Response) error
 	Bind(context.Context, *Session, *Response) error
 	Kick(context.Context, *KickMsg, *KickAnswer) error
+	PushBatch(context.Context, *PushBatchMsg, *PushBatchAnswer) error
+	// PushStream carries the pushes of a backend to every session of the
+	// frontend, see client.NewStreamPusher
+	PushStream(context.Context, McbGate_PushStreamStream) error
 }
 
 func RegisterMcbGateHandler(s server.Server, hdlr McbGateHandler, opts ...server.HandlerOption) error {
@@ -174,6 +238,8 @@ func RegisterMcbGateHandler(s server.Server, hdlr McbGateHandler, opts ...server
 		PushSession(ctx context.Context, in *Session, out *Response) error
 		Bind(ctx context.Context, in *Session, out *Response) error
 		Kick(ctx context.Context, in *KickMsg, out *KickAnswer) error
+		PushBatch(ctx context.Context, in *PushBatchMsg, out *PushBatchAnswer) error
+		PushStream(ctx context.Context, stream server.Stream) error
 	}
 	type McbGate struct {
 		mcbGate
@@ -201,3 +267,50 @@ func (h *mcbGateHandler) Bind(ctx context.Context, in *Session, out *Response) e
 func (h *mcbGateHandler) Kick(ctx context.Context, in *KickMsg, out *KickAnswer) error {
 	return h.McbGateHandler.Kick(ctx, in, out)
 }
+
+func (h *mcbGateHandler) PushBatch(ctx context.Context, in *PushBatchMsg, out *PushBatchAnswer) error {
+	return h.McbGateHandler.PushBatch(ctx, in, out)
+}
+
+func (h *mcbGateHandler) PushStream(ctx context.Context, stream server.Stream) error {
+	return h.McbGateHandler.PushStream(ctx, &mcbGatePushStreamStream{stream})
+}
+
+type McbGate_PushStreamStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	SendAndClose(*PushStreamAnswer) error
+	Recv() (*PushMsg, error)
+}
+
+type mcbGatePushStreamStream struct {
+	stream server.Stream
+}
+
+func (x *mcbGatePushStreamStream) SendAndClose(in *PushStreamAnswer) error {
+	if err := x.SendMsg(in); err != nil {
+		return err
+	}
+	return x.stream.Close()
+}
+
+func (x *mcbGatePushStreamStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *mcbGatePushStreamStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mcbGatePushStreamStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mcbGatePushStreamStream) Recv() (*PushMsg, error) {
+	m := new(PushMsg)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
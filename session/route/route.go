@@ -0,0 +1,167 @@
+// Package route compresses routes into uint16 codes, the way pomelo and
+// pitaya gates do. The client receives the dictionary at handshake and the
+// pushes and messages carry the code instead of the route string.
+//
+// Codes are stable: once a route has a code it keeps it, new routes get the
+// next free codes. Every process must use the same dictionary, so build it in
+// one place and share it with Save/Load through a store, or through the config
+// plugin with FromMap. 0 is never assigned, it means the route isn't compressed.
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/micro/micro/v3/service/store"
+)
+
+var (
+	ErrDictionaryFull = errors.New("route dictionary is full")
+	ErrDuplicateCode  = errors.New("route code is used twice")
+
+	// DefaultDictionary is used by the sessions and the router when they
+	// aren't given one, it's empty so routes aren't compressed until it's loaded
+	DefaultDictionary = NewDictionary()
+)
+
+const maxCode = 1<<16 - 1
+
+type Dictionary struct {
+	mtx    sync.RWMutex
+	codes  map[string]uint16
+	routes map[uint16]string
+	last   uint16
+}
+
+// NewDictionary returns a dictionary holding routes, past the 65535th they are
+// left uncompressed
+func NewDictionary(routes ...string) *Dictionary {
+	d := &Dictionary{
+		codes:  make(map[string]uint16),
+		routes: make(map[uint16]string),
+	}
+	d.Add(routes...)
+	return d
+}
+
+// FromMap builds a dictionary with the given codes
+func FromMap(codes map[string]uint16) (*Dictionary, error) {
+	d := NewDictionary()
+	if err := d.load(codes); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Add gives a code to the routes which don't have one yet, in sorted order
+func (d *Dictionary) Add(routes ...string) error {
+	sorted := append([]string(nil), routes...)
+	sort.Strings(sorted)
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for _, route := range sorted {
+		if _, ok := d.codes[route]; ok || route == "" {
+			continue
+		}
+		if d.last == maxCode {
+			return ErrDictionaryFull
+		}
+		d.last++
+		d.codes[route] = d.last
+		d.routes[d.last] = route
+	}
+	return nil
+}
+
+// Code returns the code of route, ok is false if it has none
+func (d *Dictionary) Code(route string) (uint16, bool) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	c, ok := d.codes[route]
+	return c, ok
+}
+
+// Route returns the route of code, ok is false if it's unknown
+func (d *Dictionary) Route(code uint16) (string, bool) {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	r, ok := d.routes[code]
+	return r, ok
+}
+
+// Map returns a copy of the codes by route
+func (d *Dictionary) Map() map[string]uint16 {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	codes := make(map[string]uint16, len(d.codes))
+	for r, c := range d.codes {
+		codes[r] = c
+	}
+	return codes
+}
+
+// Marshal encodes the dictionary as a JSON object of codes by route, the
+// format sent to the clients at handshake
+func (d *Dictionary) Marshal() ([]byte, error) {
+	return json.Marshal(d.Map())
+}
+
+// Unmarshal merges an encoded dictionary, the codes it holds win
+func (d *Dictionary) Unmarshal(b []byte) error {
+	var codes map[string]uint16
+	if err := json.Unmarshal(b, &codes); err != nil {
+		return err
+	}
+	return d.load(codes)
+}
+
+// Save writes the dictionary to st under key
+func (d *Dictionary) Save(st store.Store, key string) error {
+	b, err := d.Marshal()
+	if err != nil {
+		return err
+	}
+	return st.Write(&store.Record{Key: key, Value: b})
+}
+
+// Load merges the dictionary saved in st under key
+func (d *Dictionary) Load(st store.Store, key string) error {
+	recs, err := st.Read(key)
+	if err != nil {
+		return err
+	}
+	if len(recs) == 0 {
+		return store.ErrNotFound
+	}
+	return d.Unmarshal(recs[0].Value)
+}
+
+func (d *Dictionary) load(codes map[string]uint16) error {
+	seen := make(map[uint16]string, len(codes))
+	for r, c := range codes {
+		if other, ok := seen[c]; (ok && other != r) || c == 0 {
+			return ErrDuplicateCode
+		}
+		seen[c] = r
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	for r, c := range codes {
+		if old, ok := d.codes[r]; ok {
+			delete(d.routes, old)
+		}
+		if old, ok := d.routes[c]; ok {
+			delete(d.codes, old)
+		}
+		d.codes[r] = c
+		d.routes[c] = r
+		if c > d.last {
+			d.last = c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/store/memory"
+)
+
+func TestDictionaryStable(t *testing.T) {
+	d := NewDictionary("room.onJoin", "room.join", "chat.send")
+	code, _ := d.Code("room.join")
+
+	// new routes don't move the existing codes
+	d.Add("aaa.first", "room.leave")
+	if c, _ := d.Code("room.join"); c != code {
+		t.Errorf("room.join code = %d, want %d", c, code)
+	}
+	if c, _ := d.Code("aaa.first"); c != 4 {
+		t.Errorf("aaa.first code = %d, want 4", c)
+	}
+	if r, ok := d.Route(code); !ok || r != "room.join" {
+		t.Errorf("Route(%d) = %s, want room.join", code, r)
+	}
+	if _, ok := d.Code("room.unknown"); ok {
+		t.Errorf("room.unknown has a code")
+	}
+}
+
+func TestDictionaryShare(t *testing.T) {
+	st := memory.NewStore()
+	d := NewDictionary("room.join", "room.leave")
+	if err := d.Save(st, "routes"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := NewDictionary()
+	if err := got.Load(st, "routes"); err != nil {
+		t.Fatal(err)
+	}
+	for r, c := range d.Map() {
+		if gc, _ := got.Code(r); gc != c {
+			t.Errorf("%s code = %d, want %d", r, gc, c)
+		}
+	}
+	got.Add("room.chat")
+	if c, _ := got.Code("room.chat"); c != 3 {
+		t.Errorf("room.chat code = %d, want 3", c)
+	}
+
+	if _, err := FromMap(map[string]uint16{"a": 1, "b": 1}); err != ErrDuplicateCode {
+		t.Errorf("FromMap() error = %v, want %v", err, ErrDuplicateCode)
+	}
+}
@@ -0,0 +1,47 @@
+package router
+
+import (
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
+type Options struct {
+	// Codec decodes the messages and encodes the replies of every route,
+	// codec.DefaultMarshaler when nil
+	Codec codec.Marshaler
+	// Routes resolves the compressed routes, route.DefaultDictionary when nil
+	Routes *route.Dictionary
+	// SessionOptions are applied to the session of every request, e.g. the
+	// gate name and call options
+	SessionOptions []session.Option
+}
+type Option func(o *Options)
+
+func Codec(c codec.Marshaler) Option {
+	return func(o *Options) {
+		o.Codec = c
+	}
+}
+func Routes(d *route.Dictionary) Option {
+	return func(o *Options) {
+		o.Routes = d
+	}
+}
+func SessionOptions(opts ...session.Option) Option {
+	return func(o *Options) {
+		o.SessionOptions = append(o.SessionOptions, opts...)
+	}
+}
+
+type HandleOptions struct {
+	// Codec overrides the router codec for one route
+	Codec codec.Marshaler
+}
+type HandleOption func(o *HandleOptions)
+
+func RouteCodec(c codec.Marshaler) HandleOption {
+	return func(o *HandleOptions) {
+		o.Codec = c
+	}
+}
@@ -0,0 +1,282 @@
+// Package router implements the McbApp service of the gate. Handlers are
+// registered by route and the router decodes Request.Msg, builds the session
+// of the request and encodes the reply or the error into the Response.
+//
+//	r := router.NewRouter()
+//	r.Handle("room.join", func(ctx context.Context, req *pb.JoinReq) (*pb.JoinRsp, error) {
+//		s := session.GetSessionFromCtx(ctx)
+//		...
+//	})
+//	gate.RegisterMcbAppHandler(srv.Server(), r)
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
+var (
+	ErrInvalidHandler = errors.New("handler must be func(context.Context, *Req) (*Rsp, error) or func(context.Context, *Req) error")
+	ErrRouteExists    = errors.New("route is already registered")
+	ErrNotifyHandler  = errors.New("request sent to a notify handler")
+	ErrNotifyResponse = errors.New("handler responded to a notify")
+	ErrNoResponse     = errors.New("handler did not respond to a request")
+
+	typeOfContext = reflect.TypeOf((*context.Context)(nil)).Elem()
+	typeOfError   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type handler struct {
+	fn      reflect.Value
+	codec   codec.Marshaler
+	reqType reflect.Type
+	// hasRsp is false for func(context.Context, *Req) error
+	hasRsp bool
+}
+
+type Router struct {
+	opts Options
+
+	mtx      sync.RWMutex
+	handlers map[string]*handler
+}
+
+func NewRouter(opts ...Option) *Router {
+	var options Options
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.Codec == nil {
+		options.Codec = codec.DefaultMarshaler
+	}
+	if options.Routes == nil {
+		options.Routes = route.DefaultDictionary
+	}
+	return &Router{
+		opts:     options,
+		handlers: make(map[string]*handler),
+	}
+}
+
+func (r *Router) Options() Options {
+	return r.opts
+}
+
+// Handle registers fn for route, fn must be a
+// func(context.Context, *Req) (*Rsp, error) where Req and Rsp are messages the
+// codec of the route can handle, or a func(context.Context, *Req) error for a
+// route which only receives notifies
+func (r *Router) Handle(route string, fn interface{}, opts ...HandleOption) error {
+	options := HandleOptions{Codec: r.opts.Codec}
+	for _, o := range opts {
+		o(&options)
+	}
+	h, err := newHandler(fn)
+	if err != nil {
+		return err
+	}
+	h.codec = options.Codec
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.handlers[route]; ok {
+		return ErrRouteExists
+	}
+	r.handlers[route] = h
+	return nil
+}
+
+// Routes returns the registered routes
+func (r *Router) Routes() []string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	routes := make([]string, 0, len(r.handlers))
+	for route := range r.handlers {
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// Call implements pb.McbAppHandler. Handler errors are returned to the gate in
+// Response.Error, the returned error is only for failures of the call itself.
+//
+// A MsgRequest always gets exactly one Response, with Data or Error set, so the
+// client never waits on its Msg.Id forever. A MsgNotify never gets one, the
+// Response is left empty whatever the handler returns.
+func (r *Router) Call(ctx context.Context, req *pb.Request, rsp *pb.Response) error {
+	if req.Msg == nil {
+		rsp.Error = newError(merrors.BadRequest("", "empty message"))
+		return nil
+	}
+	route := req.Msg.Route
+	if len(route) == 0 && req.Msg.RouteCode != 0 {
+		name, ok := r.opts.Routes.Route(uint16(req.Msg.RouteCode))
+		if !ok {
+			logger.Errorf("protocol error: id %d, unknown route code %d", req.Msg.Id, req.Msg.RouteCode)
+			if req.Msg.Type == pb.MsgType_MsgRequest {
+				rsp.Error = newError(merrors.NotFound("", "route code %d not found", req.Msg.RouteCode))
+			}
+			return nil
+		}
+		route = name
+	}
+	switch req.Msg.Type {
+	case pb.MsgType_MsgRequest:
+		r.request(ctx, route, req, rsp)
+	case pb.MsgType_MsgNotify:
+		r.notify(ctx, route, req)
+	default:
+		logger.Errorf("protocol error: route %s, id %d, unexpected message type %s", route, req.Msg.Id, req.Msg.Type)
+		rsp.Error = newError(merrors.BadRequest("", "unexpected message type %s", req.Msg.Type))
+	}
+	return nil
+}
+
+func (r *Router) request(ctx context.Context, route string, req *pb.Request, rsp *pb.Response) {
+	h, ok := r.handler(route)
+	if !ok {
+		rsp.Error = newError(merrors.NotFound("", "route %s not found", route))
+		return
+	}
+	if !h.hasRsp {
+		logger.Errorf("protocol error: route %s, id %d, %v", route, req.Msg.Id, ErrNotifyHandler)
+		rsp.Error = newError(merrors.BadRequest("", "route %s only accepts notifies", route))
+		return
+	}
+	out, err := h.call(r.withSession(ctx, req), route, req.Msg.Data)
+	if err != nil {
+		logger.Debugf("route %s, err:%v", route, err)
+		rsp.Error = newError(err)
+		return
+	}
+	if out == nil {
+		logger.Errorf("protocol error: route %s, id %d, %v", route, req.Msg.Id, ErrNoResponse)
+		rsp.Error = newError(merrors.InternalServerError("", "route %s did not respond", route))
+		return
+	}
+	b, err := h.codec.Marshal(out)
+	if err != nil {
+		rsp.Error = newError(merrors.InternalServerError("", "encode %s: %v", route, err))
+		return
+	}
+	rsp.Data = b
+}
+
+func (r *Router) notify(ctx context.Context, route string, req *pb.Request) {
+	h, ok := r.handler(route)
+	if !ok {
+		logger.Errorf("notify route %s not found", route)
+		return
+	}
+	out, err := h.call(r.withSession(ctx, req), route, req.Msg.Data)
+	if err != nil {
+		logger.Errorf("notify route %s, err:%v", route, err)
+		return
+	}
+	if out != nil {
+		logger.Errorf("protocol error: route %s, %v", route, ErrNotifyResponse)
+	}
+}
+
+func (r *Router) handler(route string) (*handler, bool) {
+	r.mtx.RLock()
+	h, ok := r.handlers[route]
+	r.mtx.RUnlock()
+	return h, ok
+}
+
+// call decodes the message and runs the handler, out is nil when the handler
+// has no response or returned a nil one. A panic of the handler is returned
+// as an InternalServerError
+func (h *handler) call(ctx context.Context, route string, data []byte) (out interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Errorf("protocol error: route %s, panic:%v\n%s", route, p, debug.Stack())
+			out, err = nil, merrors.InternalServerError("", "route %s panicked", route)
+		}
+	}()
+	in := reflect.New(h.reqType)
+	if err := h.codec.Unmarshal(data, in.Interface()); err != nil {
+		return nil, merrors.BadRequest("", "decode %s: %v", route, err)
+	}
+	ret := h.fn.Call([]reflect.Value{reflect.ValueOf(ctx), in})
+	if err, _ := ret[len(ret)-1].Interface().(error); err != nil {
+		return nil, err
+	}
+	if !h.hasRsp || ret[0].IsNil() {
+		return nil, nil
+	}
+	return ret[0].Interface(), nil
+}
+
+func newHandler(fn interface{}) (*handler, error) {
+	if fn == nil {
+		return nil, ErrInvalidHandler
+	}
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 2 || t.NumOut() < 1 || t.NumOut() > 2 {
+		return nil, ErrInvalidHandler
+	}
+	if t.In(0) != typeOfContext || t.In(1).Kind() != reflect.Ptr {
+		return nil, ErrInvalidHandler
+	}
+	if t.Out(t.NumOut()-1) != typeOfError {
+		return nil, ErrInvalidHandler
+	}
+	if t.NumOut() == 2 && t.Out(0).Kind() != reflect.Ptr {
+		return nil, ErrInvalidHandler
+	}
+	return &handler{fn: v, reqType: t.In(1).Elem(), hasRsp: t.NumOut() == 2}, nil
+}
+
+// withSession places the session of the request in the context the same way
+// wrapper.SessionHandler does for the metadata
+func (r *Router) withSession(ctx context.Context, req *pb.Request) context.Context {
+	if req.Session == nil {
+		return ctx
+	}
+	opts := []session.Option{session.Uid(req.Session.Uid), session.Fid(req.FrontendID), session.Sid(req.Session.Id), session.Context(ctx)}
+	s := cli.NewSession(append(opts, r.opts.SessionOptions...)...)
+	if len(req.Session.Data) > 0 {
+		data, err := session.UnmarshalData(req.Session.Data)
+		if err != nil {
+			logger.Warnf("decode session data, sid:%d, err:%v", req.Session.Id, err)
+		} else {
+			s.SetData(data)
+		}
+	}
+	return context.WithValue(ctx, session.SessionCtxKey{}, s)
+}
+
+// newError maps a handler error, micro errors and wraperrors keep their code
+func newError(err error) *pb.Error {
+	e := merrors.FromError(err)
+	if e.Code == 0 {
+		e.Code = http.StatusInternalServerError
+	}
+	if len(e.Status) == 0 {
+		e.Status = http.StatusText(int(e.Code))
+	}
+	if len(e.Detail) == 0 {
+		e.Detail = fmt.Sprint(err)
+	}
+	return &pb.Error{
+		Id:     e.Id,
+		Code:   e.Code,
+		Detail: e.Detail,
+		Status: e.Status,
+	}
+}
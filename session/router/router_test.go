@@ -0,0 +1,182 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/proto"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
+func newRequest(t *testing.T, route string, v interface{}) *pb.Request {
+	b, err := proto.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.Request{
+		Type:       pb.RPCType_User,
+		Session:    &pb.Session{Id: 1, Uid: "u1", Data: []byte(`{"room":"r1"}`)},
+		Msg:        &pb.Msg{Id: 1, Route: route, Data: b},
+		FrontendID: "gate-1",
+	}
+}
+
+func TestRouterCall(t *testing.T) {
+	r := NewRouter()
+	err := r.Handle("user.kick", func(ctx context.Context, req *pb.KickMsg) (*pb.KickAnswer, error) {
+		s := session.GetSessionFromCtx(ctx)
+		if s == nil {
+			t.Fatal("no session in context")
+		}
+		if o := s.Options(); o.Uid != "u1" || o.Fid != "gate-1" || o.Sid != 1 {
+			t.Errorf("session options = %+v", o)
+		}
+		if room := s.GetString("room"); room != "r1" {
+			t.Errorf("session data room = %s, want r1", room)
+		}
+		if req.UserId == "" {
+			return nil, merrors.BadRequest("user.kick", "empty uid")
+		}
+		return &pb.KickAnswer{Kicked: true}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp := new(pb.Response)
+	if err := r.Call(context.Background(), newRequest(t, "user.kick", &pb.KickMsg{UserId: "u2"}), rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error != nil {
+		t.Fatalf("Response.Error = %v", rsp.Error)
+	}
+	ans := new(pb.KickAnswer)
+	if err := proto.Unmarshal(rsp.Data, ans); err != nil {
+		t.Fatal(err)
+	}
+	if !ans.Kicked {
+		t.Errorf("KickAnswer.Kicked = false, want true")
+	}
+
+	rsp = new(pb.Response)
+	if err := r.Call(context.Background(), newRequest(t, "user.kick", &pb.KickMsg{}), rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error == nil || rsp.Error.Code != 400 || rsp.Error.Id != "user.kick" {
+		t.Errorf("Response.Error = %v, want a 400 from user.kick", rsp.Error)
+	}
+
+	rsp = new(pb.Response)
+	if err := r.Call(context.Background(), newRequest(t, "user.unknown", &pb.KickMsg{}), rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error == nil || rsp.Error.Code != 404 {
+		t.Errorf("Response.Error = %v, want a 404", rsp.Error)
+	}
+}
+
+func TestRouterNotify(t *testing.T) {
+	r := NewRouter()
+	var notified int
+	if err := r.Handle("room.chat", func(ctx context.Context, req *pb.PushMsg) error {
+		notified++
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Handle("room.leave", func(ctx context.Context, req *pb.KickMsg) (*pb.KickAnswer, error) {
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a notify never gets a response
+	for _, route := range []string{"room.chat", "room.leave", "room.unknown"} {
+		req := newRequest(t, route, &pb.PushMsg{Route: "chat"})
+		req.Msg.Type = pb.MsgType_MsgNotify
+		rsp := new(pb.Response)
+		if err := r.Call(context.Background(), req, rsp); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Data != nil || rsp.Error != nil {
+			t.Errorf("%s: notify got a response %v", route, rsp)
+		}
+	}
+	if notified != 1 {
+		t.Errorf("notify handler called %d times, want 1", notified)
+	}
+
+	// a request always gets one
+	for _, route := range []string{"room.chat", "room.leave"} {
+		rsp := new(pb.Response)
+		if err := r.Call(context.Background(), newRequest(t, route, &pb.KickMsg{UserId: "u2"}), rsp); err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Error == nil {
+			t.Errorf("%s: request got no error for a missing response", route)
+		}
+	}
+}
+
+func TestRouterHandleInvalid(t *testing.T) {
+	r := NewRouter()
+	for _, fn := range []interface{}{
+		nil,
+		"room.join",
+		func(req *pb.KickMsg) (*pb.KickAnswer, error) { return nil, nil },
+		func(ctx context.Context, req string) (*pb.KickAnswer, error) { return nil, nil },
+		func(ctx context.Context, req *pb.KickMsg) *pb.KickAnswer { return nil },
+	} {
+		if err := r.Handle("room.join", fn); err != ErrInvalidHandler {
+			t.Errorf("Handle(%T) error = %v, want %v", fn, err, ErrInvalidHandler)
+		}
+	}
+}
+
+func TestRouterRouteCode(t *testing.T) {
+	r := NewRouter(Routes(route.NewDictionary("user.kick")))
+	if err := r.Handle("user.kick", func(ctx context.Context, req *pb.KickMsg) (*pb.KickAnswer, error) {
+		return &pb.KickAnswer{Kicked: true}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := newRequest(t, "", &pb.KickMsg{UserId: "u2"})
+	req.Msg.RouteCode = 1
+	rsp := new(pb.Response)
+	if err := r.Call(context.Background(), req, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error != nil || len(rsp.Data) == 0 {
+		t.Errorf("Response = %v, want the user.kick answer", rsp)
+	}
+
+	req.Msg.RouteCode = 2
+	rsp = new(pb.Response)
+	if err := r.Call(context.Background(), req, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error == nil || rsp.Error.Code != 404 {
+		t.Errorf("Response.Error = %v, want a 404", rsp.Error)
+	}
+}
+
+func TestRouterPanic(t *testing.T) {
+	r := NewRouter()
+	err := r.Handle("user.kick", func(ctx context.Context, req *pb.KickMsg) (*pb.KickAnswer, error) {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp := new(pb.Response)
+	if err := r.Call(context.Background(), newRequest(t, "user.kick", &pb.KickMsg{UserId: "u2"}), rsp); err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Error == nil || rsp.Error.Code != 500 {
+		t.Errorf("Response.Error = %v, want a 500", rsp.Error)
+	}
+}
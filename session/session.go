@@ -3,24 +3,75 @@ package session
 import (
 	"context"
 	"errors"
+
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
 )
 
 var (
 	ErrIllegalUID          = errors.New("illegal uid")
 	ErrSessionAlreadyBound = errors.New("session is already bound to an uid")
 	ErrNoUIDBind           = errors.New("you have to bind an UID to the session to do that")
+	ErrDuplicateLogin      = errors.New("uid is already bound on another session")
+)
+
+// LoginPolicy is what Bind does when the uid is bound on another session
+type LoginPolicy int
+
+const (
+	// LoginAllowMulti lets the uid be bound on many sessions, the directory
+	// is not checked
+	LoginAllowMulti LoginPolicy = iota
+	// LoginReject fails the Bind with ErrDuplicateLogin, the entries of the
+	// directory must expire
+	LoginReject
+	// LoginKickOld kicks the other session with pb.KickReason_KickDuplicateLogin,
+	// the uid is taken over when its gate can't be reached
+	LoginKickOld
 )
 
+// Session is a handle to a client connection held by a gate. Every call has a
+// Ctx variant which carries the caller's deadline, cancellation and metadata to
+// the gate; the plain variants use Options().Context.
+//
+// The key/value data is local until PushSession sends it to the gate, which
+// then forwards it with every following request of the session.
 type Session interface {
 	Init(opts ...Option)
 	Options() Options
 	Bind(uid string) error
+	BindCtx(ctx context.Context, uid string) error
 	Kick() error
+	KickCtx(ctx context.Context) error
+	// KickWith kicks the user telling the client why, kicked is false when
+	// the gate had no session for the uid
+	KickWith(ctx context.Context, opts ...KickOption) (kicked bool, err error)
 	PushSession() error
+	PushSessionCtx(ctx context.Context) error
 	Push(route string, v interface{}) error
+	PushCtx(ctx context.Context, route string, v interface{}) error
+	Set(key string, v interface{})
+	Get(key string) interface{}
+	Remove(key string)
+	HasKey(key string) bool
+	SetData(data map[string]interface{})
+	GetData() map[string]interface{}
+	GetInt(key string) int
+	GetInt64(key string) int64
+	GetFloat64(key string) float64
+	GetBool(key string) bool
+	GetString(key string) string
+	BindBackend(service, serverUID string)
+	UnbindBackend(service string)
+	Backend(service string) string
 	String() string
 }
 
+// Pusher delivers the pushes of a session in place of a unary McbGate.Push,
+// e.g. the streams of client.NewStreamPusher
+type Pusher interface {
+	Push(ctx context.Context, fid string, msg *pb.PushMsg) error
+}
+
 type SessionCtxKey struct{}
 
 func GetSessionFromCtx(ctx context.Context) Session {
@@ -0,0 +1,245 @@
+// Package sessiontest provides an in-process fake of the gate to unit test
+// handlers which use the session of their context.
+//
+//	func TestJoin(t *testing.T) {
+//		g := sessiontest.NewGate()
+//		ctx, _ := g.NewContext(context.Background(), session.Uid("u1"))
+//		if err := h.Join(ctx, req, rsp); err != nil {
+//			t.Fatal(err)
+//		}
+//		g.ExpectPush(t, "u1", "room.onJoin")
+//	}
+package sessiontest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/route"
+)
+
+var _ pb.McbGateService = (*Gate)(nil)
+
+// Gate is a fake pb.McbGateService recording every call it receives
+type Gate struct {
+	// Routes resolves the compressed push routes, route.DefaultDictionary
+	// when nil
+	Routes *route.Dictionary
+
+	mtx      sync.Mutex
+	pushes   []*pb.PushMsg
+	binds    []*pb.Session
+	sessions []*pb.Session
+	kicks    []*pb.KickMsg
+	offline  map[string]bool
+}
+
+func NewGate() *Gate {
+	return &Gate{offline: make(map[string]bool)}
+}
+
+// NewSession returns a session whose calls go to the fake gate
+func (g *Gate) NewSession(opts ...session.Option) session.Session {
+	return cli.NewSession(append([]session.Option{session.Fid("sessiontest"), session.Gate(g)}, opts...)...)
+}
+
+// NewContext returns ctx holding a session of the fake gate, the way
+// wrapper.SessionHandler sets it up for a request coming from the gate
+func (g *Gate) NewContext(ctx context.Context, opts ...session.Option) (context.Context, session.Session) {
+	s := g.NewSession(append([]session.Option{session.Context(ctx)}, opts...)...)
+	return context.WithValue(ctx, session.SessionCtxKey{}, s), s
+}
+
+// SetOffline makes the batch pushes report uids as not online and the kicks
+// of uids answer they were not kicked
+func (g *Gate) SetOffline(uids ...string) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	for _, uid := range uids {
+		g.offline[uid] = true
+	}
+}
+
+// Reset forgets the recorded calls
+func (g *Gate) Reset() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	g.pushes, g.binds, g.sessions, g.kicks = nil, nil, nil, nil
+}
+
+func (g *Gate) Pushes() []*pb.PushMsg {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return append([]*pb.PushMsg(nil), g.pushes...)
+}
+
+func (g *Gate) Binds() []*pb.Session {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return append([]*pb.Session(nil), g.binds...)
+}
+
+// Sessions returns the sessions received by PushSession
+func (g *Gate) Sessions() []*pb.Session {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return append([]*pb.Session(nil), g.sessions...)
+}
+
+func (g *Gate) Kicks() []*pb.KickMsg {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return append([]*pb.KickMsg(nil), g.kicks...)
+}
+
+// ExpectPush fails the test if nothing was pushed to uid on route, it returns
+// the first matching push so its data can be checked
+func (g *Gate) ExpectPush(t testing.TB, uid, route string) *pb.PushMsg {
+	t.Helper()
+	for _, p := range g.Pushes() {
+		if p.Uid == uid && p.Route == route {
+			return p
+		}
+	}
+	t.Errorf("sessiontest: no push to %s on %s", uid, route)
+	return nil
+}
+
+// ExpectNoPush fails the test if anything was pushed to uid on route
+func (g *Gate) ExpectNoPush(t testing.TB, uid, route string) {
+	t.Helper()
+	for _, p := range g.Pushes() {
+		if p.Uid == uid && p.Route == route {
+			t.Errorf("sessiontest: unexpected push to %s on %s", uid, route)
+			return
+		}
+	}
+}
+
+func (g *Gate) ExpectBind(t testing.TB, uid string) *pb.Session {
+	t.Helper()
+	for _, b := range g.Binds() {
+		if b.Uid == uid {
+			return b
+		}
+	}
+	t.Errorf("sessiontest: %s was not bound", uid)
+	return nil
+}
+
+func (g *Gate) ExpectKick(t testing.TB, uid string) *pb.KickMsg {
+	t.Helper()
+	for _, k := range g.Kicks() {
+		if k.UserId == uid {
+			return k
+		}
+	}
+	t.Errorf("sessiontest: %s was not kicked", uid)
+	return nil
+}
+
+func (g *Gate) Push(ctx context.Context, in *pb.PushMsg, opts ...client.CallOption) (*pb.Response, error) {
+	g.record(in)
+	return &pb.Response{}, nil
+}
+
+func (g *Gate) PushSession(ctx context.Context, in *pb.Session, opts ...client.CallOption) (*pb.Response, error) {
+	g.mtx.Lock()
+	g.sessions = append(g.sessions, in)
+	g.mtx.Unlock()
+	return &pb.Response{}, nil
+}
+
+func (g *Gate) Bind(ctx context.Context, in *pb.Session, opts ...client.CallOption) (*pb.Response, error) {
+	g.mtx.Lock()
+	g.binds = append(g.binds, in)
+	g.mtx.Unlock()
+	return &pb.Response{}, nil
+}
+
+func (g *Gate) Kick(ctx context.Context, in *pb.KickMsg, opts ...client.CallOption) (*pb.KickAnswer, error) {
+	g.mtx.Lock()
+	g.kicks = append(g.kicks, in)
+	kicked := !g.offline[in.UserId]
+	g.mtx.Unlock()
+	return &pb.KickAnswer{Kicked: kicked}, nil
+}
+
+// PushBatch records one push per delivered uid, Broadcast is recorded with
+// an empty uid
+func (g *Gate) PushBatch(ctx context.Context, in *pb.PushBatchMsg, opts ...client.CallOption) (*pb.PushBatchAnswer, error) {
+	rsp := new(pb.PushBatchAnswer)
+	if in.All {
+		g.record(&pb.PushMsg{Route: in.Route, RouteCode: in.RouteCode, Data: in.Data})
+		return rsp, nil
+	}
+	for _, uid := range in.Uids {
+		g.mtx.Lock()
+		offline := g.offline[uid]
+		g.mtx.Unlock()
+		if offline {
+			rsp.FailedUids = append(rsp.FailedUids, uid)
+			continue
+		}
+		g.record(&pb.PushMsg{Route: in.Route, RouteCode: in.RouteCode, Uid: uid, Data: in.Data})
+	}
+	return rsp, nil
+}
+
+// PushStream returns a stream recording every push it carries
+func (g *Gate) PushStream(ctx context.Context, opts ...client.CallOption) (pb.McbGate_PushStreamService, error) {
+	return &pushStream{g: g, ctx: ctx}, nil
+}
+
+type pushStream struct {
+	g      *Gate
+	ctx    context.Context
+	pushed uint64
+}
+
+func (s *pushStream) Context() context.Context {
+	return s.ctx
+}
+func (s *pushStream) SendMsg(m interface{}) error {
+	in, ok := m.(*pb.PushMsg)
+	if !ok {
+		return errors.New("sessiontest: not a push")
+	}
+	return s.Send(in)
+}
+func (s *pushStream) RecvMsg(m interface{}) error {
+	return io.EOF
+}
+func (s *pushStream) CloseAndRecv() (*pb.PushStreamAnswer, error) {
+	return &pb.PushStreamAnswer{Pushed: atomic.LoadUint64(&s.pushed)}, nil
+}
+func (s *pushStream) Send(in *pb.PushMsg) error {
+	s.g.record(in)
+	atomic.AddUint64(&s.pushed, 1)
+	return nil
+}
+
+// record keeps a copy of a push with its route uncompressed, the caller may
+// reuse the buffer of Data
+func (g *Gate) record(in *pb.PushMsg) {
+	routes := g.Routes
+	if routes == nil {
+		routes = route.DefaultDictionary
+	}
+	data := append([]byte(nil), in.Data...)
+	p := &pb.PushMsg{Route: in.Route, Uid: in.Uid, Data: data, RouteCode: in.RouteCode, Seq: in.Seq}
+	if len(p.Route) == 0 && p.RouteCode != 0 {
+		p.Route, _ = routes.Route(uint16(p.RouteCode))
+	}
+	g.mtx.Lock()
+	g.pushes = append(g.pushes, p)
+	g.mtx.Unlock()
+}
@@ -0,0 +1,334 @@
+package sessiontest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/micro/micro/v3/service/client"
+	merrors "github.com/micro/micro/v3/service/errors"
+	"github.com/micro/micro/v3/service/store/memory"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec/proto"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/directory"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
+	pb "github.com/wolfplus2048/mcbeam-plugins/session/v3/proto"
+)
+
+func join(ctx context.Context, uid string) error {
+	s := session.GetSessionFromCtx(ctx)
+	if err := s.Bind(uid); err != nil {
+		return err
+	}
+	return s.PushCtx(ctx, "room.onJoin", &pb.KickAnswer{Kicked: true})
+}
+
+func TestGate(t *testing.T) {
+	g := NewGate()
+	ctx, _ := g.NewContext(context.Background(), session.Sid(1))
+	if err := join(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	g.ExpectBind(t, "u1")
+	if p := g.ExpectPush(t, "u1", "room.onJoin"); p != nil && len(p.Data) == 0 {
+		t.Errorf("push has no data")
+	}
+	g.ExpectNoPush(t, "u1", "room.onLeave")
+
+	// every push keeps its own payload
+	s := session.GetSessionFromCtx(ctx)
+	uids := []string{"aaaa", "bbbb", "cccc"}
+	for _, uid := range uids {
+		if err := s.PushCtx(ctx, "room.onKick", &pb.KickMsg{UserId: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	for _, p := range g.Pushes() {
+		if p.Route != "room.onKick" {
+			continue
+		}
+		msg := new(pb.KickMsg)
+		if err := proto.Unmarshal(p.Data, msg); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, msg.UserId)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(uids) {
+		t.Errorf("pushed uids = %v, want %v", got, uids)
+	}
+	// the fake doesn't keep the buffer of the caller
+	data := []byte("dddd")
+	if _, err := g.Push(ctx, &pb.PushMsg{Uid: "u1", Route: "room.onRaw", Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	copy(data, "eeee")
+	if p := g.ExpectPush(t, "u1", "room.onRaw"); p != nil && string(p.Data) != "dddd" {
+		t.Errorf("push data = %q, want dddd", p.Data)
+	}
+
+	g.SetOffline("u3")
+	failed, err := cli.PushToUIDs(ctx, "sessiontest", "room.onChat", []string{"u2", "u3"}, &pb.KickAnswer{}, session.Gate(g))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 1 || failed[0] != "u3" {
+		t.Errorf("PushToUIDs() failed = %v, want [u3]", failed)
+	}
+	g.ExpectPush(t, "u2", "room.onChat")
+}
+
+func TestKickWith(t *testing.T) {
+	g := NewGate()
+	s := g.NewSession(session.Sid(1), session.Uid("u1"))
+	kicked, err := s.KickWith(context.Background(), session.KickReason(pb.KickReason_KickBanned), session.KickMessage("cheating"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !kicked {
+		t.Errorf("KickWith() kicked = false, want true")
+	}
+	if k := g.ExpectKick(t, "u1"); k != nil && (k.Reason != pb.KickReason_KickBanned || k.Message != "cheating") {
+		t.Errorf("kick reason = %v %q", k.Reason, k.Message)
+	}
+
+	g.SetOffline("u2")
+	kicked, err = g.NewSession(session.Sid(2), session.Uid("u2")).KickWith(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kicked {
+		t.Errorf("KickWith() kicked = true for an offline uid")
+	}
+}
+
+func TestDuplicateLogin(t *testing.T) {
+	g := NewGate()
+	dir := directory.NewDirectory(directory.Store(memory.NewStore()), directory.Sync(NewSync()), directory.Expiry(time.Minute))
+	ctx := context.Background()
+
+	old := g.NewSession(session.Fid("gate-1"), session.Sid(1), session.Directory(dir), session.DuplicateLogin(session.LoginReject))
+	if err := old.BindCtx(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	s := g.NewSession(session.Fid("gate-2"), session.Sid(2), session.Directory(dir), session.DuplicateLogin(session.LoginReject))
+	if err := s.BindCtx(ctx, "u1"); err != session.ErrDuplicateLogin {
+		t.Fatalf("BindCtx() error = %v, want %v", err, session.ErrDuplicateLogin)
+	}
+
+	s = g.NewSession(session.Fid("gate-2"), session.Sid(3), session.Directory(dir), session.DuplicateLogin(session.LoginKickOld))
+	if err := s.BindCtx(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if k := g.ExpectKick(t, "u1"); k != nil && k.Reason != pb.KickReason_KickDuplicateLogin {
+		t.Errorf("kick reason = %v, want %v", k.Reason, pb.KickReason_KickDuplicateLogin)
+	}
+	e, err := dir.Lookup("u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Fid != "gate-2" || e.Sid != 3 {
+		t.Errorf("Lookup() = %+v, want gate-2/3", e)
+	}
+
+	// the gate of gate-2 answers with an error, the old session may be alive
+	denied := merrors.Forbidden("mcb.gate", "denied")
+	s = cli.NewSession(session.Gate(kickErrGate{g, denied}), session.Fid("gate-3"), session.Sid(4), session.Directory(dir), session.DuplicateLogin(session.LoginKickOld))
+	if err := s.BindCtx(ctx, "u1"); merrors.FromError(err).Code != 403 {
+		t.Errorf("BindCtx() error = %v, want %v", err, denied)
+	}
+	// the caller gives up
+	down := merrors.InternalServerError("go.micro.client", "service gate: not found")
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	s = cli.NewSession(session.Gate(kickErrGate{g, down}), session.Fid("gate-3"), session.Sid(5), session.Directory(dir), session.DuplicateLogin(session.LoginKickOld))
+	if err := s.BindCtx(cctx, "u1"); err == nil {
+		t.Errorf("BindCtx() error = nil with a cancelled context")
+	}
+	if e, err := dir.Lookup("u1"); err != nil || e.Fid != "gate-2" {
+		t.Errorf("Lookup() = %+v, %v, want gate-2", e, err)
+	}
+
+	// the gate of gate-2 died, the uid is taken over
+	s = cli.NewSession(session.Gate(kickErrGate{g, down}), session.Fid("gate-3"), session.Sid(6), session.Directory(dir), session.DuplicateLogin(session.LoginKickOld))
+	if err := s.BindCtx(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if e, err := dir.Lookup("u1"); err != nil || e.Fid != "gate-3" {
+		t.Errorf("Lookup() = %+v, %v, want gate-3", e, err)
+	}
+}
+
+func TestNewSessionByUID(t *testing.T) {
+	g := NewGate()
+	dir := directory.NewDirectory(directory.Store(memory.NewStore()))
+	if err := dir.Bind("u1", &directory.Entry{Fid: "gate-2", Sid: 5, Gate: "ws-gate"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := cli.NewSessionByUID("u1", session.Gate(g), session.Directory(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o := s.Options(); o.Uid != "u1" || o.Fid != "gate-2" || o.Sid != 5 || o.GateName != "ws-gate" {
+		t.Errorf("session = %s/%s/%d/%s, want u1/gate-2/5/ws-gate", o.Uid, o.Fid, o.Sid, o.GateName)
+	}
+	if err := s.PushCtx(context.Background(), "room.onJoin", &pb.KickAnswer{}); err != nil {
+		t.Fatal(err)
+	}
+	g.ExpectPush(t, "u1", "room.onJoin")
+
+	if _, err := cli.NewSessionByUID("u2", session.Gate(g), session.Directory(dir)); err != directory.ErrNotFound {
+		t.Errorf("NewSessionByUID() error = %v, want %v", err, directory.ErrNotFound)
+	}
+}
+
+func TestDuplicateLoginDirectory(t *testing.T) {
+	g := NewGate()
+	ctx := context.Background()
+
+	dir := directory.NewDirectory(directory.Store(memory.NewStore()), directory.Sync(NewSync()))
+	s := g.NewSession(session.Sid(1), session.Directory(dir), session.DuplicateLogin(session.LoginReject))
+	if err := s.BindCtx(ctx, "u1"); err != directory.ErrNoExpiry {
+		t.Errorf("BindCtx() error = %v, want %v", err, directory.ErrNoExpiry)
+	}
+	dir = directory.NewDirectory(directory.Store(memory.NewStore()))
+	s = g.NewSession(session.Sid(2), session.Directory(dir), session.DuplicateLogin(session.LoginKickOld))
+	if err := s.BindCtx(ctx, "u1"); err != directory.ErrNoSync {
+		t.Errorf("BindCtx() error = %v, want %v", err, directory.ErrNoSync)
+	}
+}
+
+// kickErrGate fails the kicks with err
+type kickErrGate struct {
+	*Gate
+	err error
+}
+
+func (g kickErrGate) Kick(ctx context.Context, in *pb.KickMsg, opts ...client.CallOption) (*pb.KickAnswer, error) {
+	return nil, g.err
+}
+
+func TestStreamPusher(t *testing.T) {
+	g := NewGate()
+	p := cli.NewStreamPusher(cli.QueueSize(1), cli.SessionOptions(session.Gate(g)))
+	s := g.NewSession(session.Fid("gate-1"), session.Sid(1), session.Uid("u1"), session.WithPusher(p))
+	uids := []string{"aaaa", "bbbb", "cccc", "dddd", "eeee", "ffff", "gggg", "hhhh", "iiii", "jjjj"}
+	for _, uid := range uids {
+		if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickMsg{UserId: uid}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	pushes := g.Pushes()
+	if len(pushes) != len(uids) {
+		t.Fatalf("%d pushes, want %d", len(pushes), len(uids))
+	}
+	// each push keeps its own payload while it waits in the queue
+	for i, push := range pushes {
+		msg := new(pb.KickMsg)
+		if err := proto.Unmarshal(push.Data, msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.UserId != uids[i] {
+			t.Errorf("push #%d uid = %q, want %q", i, msg.UserId, uids[i])
+		}
+	}
+	if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickAnswer{}); err != cli.ErrPusherClosed {
+		t.Errorf("PushCtx() error = %v, want %v", err, cli.ErrPusherClosed)
+	}
+}
+
+func TestStreamPusherIdle(t *testing.T) {
+	g := NewGate()
+	n := runtime.NumGoroutine()
+	p := cli.NewStreamPusher(cli.IdleTimeout(10*time.Millisecond), cli.SessionOptions(session.Gate(g)))
+	defer p.Close()
+	for i := 0; i < 20; i++ {
+		s := g.NewSession(session.Fid("gate-"+strconv.Itoa(i)), session.Sid(1), session.Uid("u1"), session.WithPusher(p))
+		if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickMsg{UserId: "u1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the streams of the idle frontends are gone with their goroutines
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m := runtime.NumGoroutine(); m > n {
+		t.Errorf("%d goroutines after the streams went idle, want %d", m, n)
+	}
+
+	// a push to a forgotten frontend opens its stream again
+	s := g.NewSession(session.Fid("gate-0"), session.Sid(1), session.Uid("u1"), session.WithPusher(p))
+	if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickMsg{UserId: "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(g.Pushes()); n != 21 {
+		t.Errorf("%d pushes, want 21", n)
+	}
+}
+
+func TestOrderedPusher(t *testing.T) {
+	g := NewGate()
+	p := cli.NewOrderedPusher(nil, session.Gate(g))
+	s := g.NewSession(session.Fid("gate-1"), session.Sid(1), session.Uid("u1"), session.WithPusher(p))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickAnswer{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var gaps [][2]uint64
+	d := cli.NewGapDetector(func(uid string, from, to uint64) {
+		gaps = append(gaps, [2]uint64{from, to})
+	})
+	for i, push := range g.Pushes() {
+		if push.Seq != uint64(i+1) {
+			t.Fatalf("push %d has seq %d", i, push.Seq)
+		}
+		// the client misses seq 5 and 6
+		if push.Seq == 5 || push.Seq == 6 {
+			continue
+		}
+		if !d.Observe(push) {
+			t.Errorf("Observe() = false for seq %d", push.Seq)
+		}
+	}
+	if len(gaps) != 1 || gaps[0] != [2]uint64{5, 6} {
+		t.Errorf("gaps = %v, want [[5 6]]", gaps)
+	}
+	if d.Observe(&pb.PushMsg{Uid: "u1", Seq: 3}) {
+		t.Errorf("Observe() = true for an old seq")
+	}
+}
+
+func TestPushLimiter(t *testing.T) {
+	g := NewGate()
+	s := g.NewSession(session.Uid("u1"), session.PushLimiter(limiter.NewLimiter(limiter.Rate(0.001), limiter.Burst(2))))
+	for i := 0; i < 2; i++ {
+		if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickAnswer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.PushCtx(context.Background(), "room.onMove", &pb.KickAnswer{}); err != limiter.ErrLimited {
+		t.Errorf("PushCtx() error = %v, want %v", err, limiter.ErrLimited)
+	}
+}
@@ -0,0 +1,64 @@
+package sessiontest
+
+import (
+	"errors"
+	"sync"
+
+	msync "github.com/micro/micro/v3/service/sync"
+)
+
+var _ msync.Sync = (*Sync)(nil)
+
+// Sync is an in-process msync.Sync holding locks only, for the directory of
+// the sessions bound with session.LoginReject or session.LoginKickOld
+type Sync struct {
+	mtx   sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func NewSync() *Sync {
+	return &Sync{locks: make(map[string]chan struct{})}
+}
+
+func (s *Sync) Init(...msync.Option) error {
+	return nil
+}
+
+func (s *Sync) Options() msync.Options {
+	return msync.Options{}
+}
+
+func (s *Sync) Leader(id string, opts ...msync.LeaderOption) (msync.Leader, error) {
+	return nil, errors.New("sessiontest: leader election is not supported")
+}
+
+// Lock waits for the lock of id, the options are ignored
+func (s *Sync) Lock(id string, opts ...msync.LockOption) error {
+	for {
+		s.mtx.Lock()
+		l, ok := s.locks[id]
+		if !ok {
+			s.locks[id] = make(chan struct{})
+			s.mtx.Unlock()
+			return nil
+		}
+		s.mtx.Unlock()
+		<-l
+	}
+}
+
+func (s *Sync) Unlock(id string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	l, ok := s.locks[id]
+	if !ok {
+		return errors.New("sessiontest: lock not found")
+	}
+	delete(s.locks, id)
+	close(l)
+	return nil
+}
+
+func (s *Sync) String() string {
+	return "sessiontest"
+}
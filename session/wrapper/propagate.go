@@ -0,0 +1,69 @@
+package wrapper
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/logger"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+)
+
+type sessionClient struct {
+	client.Client
+}
+
+func (c *sessionClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	return c.Client.Call(propagate(ctx), req, rsp, opts...)
+}
+
+func (c *sessionClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return c.Client.Stream(propagate(ctx), req, opts...)
+}
+
+// propagate writes the session of ctx in the metadata the way the gate does,
+// so the SessionHandler of the called service finds the same session
+func propagate(ctx context.Context) context.Context {
+	s := session.GetSessionFromCtx(ctx)
+	if s == nil {
+		return ctx
+	}
+	opts := s.Options()
+	md := metadata.Metadata{
+		"mcb-session-id":  strconv.FormatInt(opts.Sid, 10),
+		"mcb-session-fid": opts.Fid,
+		"mcb-session-uid": opts.Uid,
+	}
+	if len(opts.GateName) > 0 {
+		md["mcb-session-gate"] = opts.GateName
+	}
+	if opts.Codec != nil {
+		md["mcb-session-codec"] = opts.Codec.String()
+	}
+	data, err := encodeData(s)
+	if err != nil {
+		logger.Warnf("encode session data, sid:%d, err:%v", opts.Sid, err)
+	} else if len(data) > 0 {
+		md["mcb-session-data"] = data
+	}
+	return metadata.MergeContext(ctx, md, true)
+}
+
+func encodeData(s session.Session) (string, error) {
+	b, err := session.MarshalData(s.GetData())
+	if err != nil || len(b) == 0 {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// SessionClient forwards the session of the context of a session handler on
+// the outgoing calls, so a player can be pushed to from any service down the
+// call chain. Wrap the client of the services called with a session context.
+func SessionClient() client.Wrapper {
+	return func(c client.Client) client.Client {
+		return &sessionClient{c}
+	}
+}
@@ -0,0 +1,59 @@
+package wrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/server"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+)
+
+// captureClient keeps the context of the last call
+type captureClient struct {
+	client.Client
+	ctx context.Context
+}
+
+func (c *captureClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	c.ctx = ctx
+	return nil
+}
+
+func TestSessionClient(t *testing.T) {
+	json, _ := codec.Get("json")
+	s := cli.NewSession(session.Sid(7), session.Fid("gate-1"), session.Uid("u1"), session.GateName("ws-gate"), session.Codec(json))
+	s.Set("room", "r1")
+	ctx := context.WithValue(context.Background(), session.SessionCtxKey{}, s)
+
+	capture := new(captureClient)
+	c := SessionClient()(capture)
+	if err := c.Call(ctx, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the called service rebuilds the session from the metadata
+	var got session.Session
+	h := SessionHandler()(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		got = session.GetSessionFromCtx(ctx)
+		return nil
+	})
+	if err := h(capture.ctx, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("no session in the context")
+	}
+	opts := got.Options()
+	if opts.Sid != 7 || opts.Fid != "gate-1" || opts.Uid != "u1" || opts.GateName != "ws-gate" {
+		t.Errorf("session = %d/%s/%s/%s", opts.Sid, opts.Fid, opts.Uid, opts.GateName)
+	}
+	if opts.Codec == nil || opts.Codec.String() != json.String() {
+		t.Errorf("codec = %v, want %s", opts.Codec, json)
+	}
+	if got.GetString("room") != "r1" {
+		t.Errorf("data room = %q, want r1", got.GetString("room"))
+	}
+}
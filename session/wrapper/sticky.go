@@ -0,0 +1,43 @@
+package wrapper
+
+import (
+	"context"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+)
+
+type stickyClient struct {
+	client.Client
+}
+
+func (c *stickyClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	return c.Client.Call(ctx, req, rsp, stickyOptions(ctx, req, opts)...)
+}
+
+func (c *stickyClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return c.Client.Stream(ctx, req, stickyOptions(ctx, req, opts)...)
+}
+
+// stickyOptions routes the call to the server the session is bound to for
+// the service, explicit options given by the caller still win
+func stickyOptions(ctx context.Context, req client.Request, opts []client.CallOption) []client.CallOption {
+	s := session.GetSessionFromCtx(ctx)
+	if s == nil {
+		return opts
+	}
+	uid := s.Backend(req.Service())
+	if uid == "" {
+		return opts
+	}
+	return append([]client.CallOption{client.WithServerUid(uid)}, opts...)
+}
+
+// StickyClient sends the calls made with the context of a session handler to
+// the instance bound with Session.BindBackend, the same way session/client
+// reaches the gate of the session
+func StickyClient() client.Wrapper {
+	return func(c client.Client) client.Client {
+		return &stickyClient{c}
+	}
+}
@@ -0,0 +1,90 @@
+package wrapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/micro/micro/v3/service/router"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+)
+
+// optsClient keeps the call options of the last call
+type optsClient struct {
+	client.Client
+	opts []client.CallOption
+}
+
+func (c *optsClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	c.opts = opts
+	return nil
+}
+
+type serviceRequest struct {
+	client.Request
+	service string
+}
+
+func (r serviceRequest) Service() string {
+	return r.service
+}
+
+// uidRouter answers a lookup with the server uid it was asked for
+type uidRouter struct {
+	router.Router
+}
+
+func (r uidRouter) Lookup(service string, opts ...router.LookupOption) ([]router.Route, error) {
+	var options router.LookupOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	return []router.Route{{Service: service, Address: options.Uid}}, nil
+}
+
+// serverUid is the server uid the client would look the route up with
+func serverUid(t *testing.T, req client.Request, opts []client.CallOption) string {
+	options := client.CallOptions{Router: uidRouter{}}
+	for _, o := range opts {
+		o(&options)
+	}
+	addrs, err := client.LookupRoute(context.Background(), req, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addrs[0]
+}
+
+func TestStickyClient(t *testing.T) {
+	s := cli.NewSession(session.Sid(7), session.Fid("gate-1"), session.Uid("u1"))
+	s.BindBackend("room", "room-2")
+	ctx := context.WithValue(context.Background(), session.SessionCtxKey{}, s)
+
+	capture := new(optsClient)
+	c := StickyClient()(capture)
+	req := serviceRequest{service: "room"}
+
+	if err := c.Call(ctx, req, nil); err != nil {
+		t.Fatal(err)
+	}
+	if uid := serverUid(t, req, capture.opts); uid != "room-2" {
+		t.Errorf("server uid = %q, want room-2 from the session", uid)
+	}
+
+	if err := c.Call(ctx, req, nil, client.WithServerUid("room-3")); err != nil {
+		t.Fatal(err)
+	}
+	if uid := serverUid(t, req, capture.opts); uid != "room-3" {
+		t.Errorf("server uid = %q, want room-3 from the call option", uid)
+	}
+
+	// another service isn't bound, its calls go anywhere
+	other := serviceRequest{service: "chat"}
+	if err := c.Call(ctx, other, nil); err != nil {
+		t.Fatal(err)
+	}
+	if uid := serverUid(t, other, capture.opts); uid != "" {
+		t.Errorf("server uid = %q, want none", uid)
+	}
+}
@@ -2,14 +2,21 @@ package wrapper
 
 import (
 	"context"
+	"encoding/base64"
 	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/server"
 	"github.com/wolfplus2048/mcbeam-plugins/session/v3"
 	cli "github.com/wolfplus2048/mcbeam-plugins/session/v3/client"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/codec"
+	"github.com/wolfplus2048/mcbeam-plugins/session/v3/limiter"
 	"strconv"
 )
 
-func SessionHandler() server.HandlerWrapper {
+// SessionHandler places the session of the requests coming from a gate in the
+// context, opts are applied to every session, e.g. session.CallOptions, before
+// the ones the gate sets in the metadata
+func SessionHandler(opts ...session.Option) server.HandlerWrapper {
 	return func(h server.HandlerFunc) server.HandlerFunc {
 		return func(ctx context.Context, req server.Request, rsp interface{}) error {
 			uid, _ := metadata.Get(ctx, "mcb-session-uid")
@@ -23,10 +30,54 @@ func SessionHandler() server.HandlerWrapper {
 			}
 			sid, _ := strconv.ParseInt(_id, 10, 64)
 
-			s := cli.NewSession(session.Uid(uid), session.Fid(fid), session.Sid(sid))
+			// the session inherits the inbound context so deadlines and trace
+			// metadata reach the gate
+			sopts := append([]session.Option{session.Uid(uid), session.Fid(fid), session.Sid(sid), session.Context(ctx)}, opts...)
+
+			// the gate tells its service name when it isn't the default one
+			if name, ok := metadata.Get(ctx, "mcb-session-gate"); ok && name != "" {
+				sopts = append(sopts, session.GateName(name))
+			}
+
+			// the gate tells which codec its client speaks
+			if name, ok := metadata.Get(ctx, "mcb-session-codec"); ok {
+				if c, ok := codec.Get(name); ok {
+					sopts = append(sopts, session.Codec(c))
+				} else {
+					logger.Warnf("unknown session codec %s, sid:%d", name, sid)
+				}
+			}
+			s := cli.NewSession(sopts...)
+
+			// the gate forwards the pushed session data base64 encoded
+			if _data, ok := metadata.Get(ctx, "mcb-session-data"); ok && _data != "" {
+				data, err := decodeData(_data)
+				if err != nil {
+					logger.Warnf("decode session data, sid:%d, err:%v", sid, err)
+				} else {
+					s.SetData(data)
+				}
+			}
 			ctx = context.WithValue(ctx, session.SessionCtxKey{}, s)
 
+			// the request goes on when the limiter fails
+			if l := s.Options().RequestLimiter; l != nil {
+				ok, err := l.Allow(ctx, limiter.Key(uid, fid, sid))
+				if err != nil {
+					logger.Warnf("request limiter, sid:%d, err:%v", sid, err)
+				} else if !ok {
+					return limiter.ErrLimited
+				}
+			}
 			return h(ctx, req, rsp)
 		}
 	}
 }
+
+func decodeData(v string) (map[string]interface{}, error) {
+	b, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	return session.UnmarshalData(b)
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// 哈希的字段以 record 返回，字段名在 Metadata["field"]
+func (r *rkv) readHashFields(key string, fields []string, options *store.ReadOptions) ([]*store.Record, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	values, err := r.Client.HMGet(options.Context, key, fields...).Result()
+	if nil != err {
+		return nil, err
+	}
+	records := make([]*store.Record, 0, len(fields))
+	for idx, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			// 字段不存在
+			continue
+		}
+		records = append(records, hashRecord(strings.TrimPrefix(key, options.Table), fields[idx], s))
+	}
+	return records, nil
+}
+
+func (r *rkv) readHashAll(key string, options *store.ReadOptions) ([]*store.Record, error) {
+	values, err := r.Client.HGetAll(options.Context, key).Result()
+	if nil != err {
+		return nil, err
+	}
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	records := make([]*store.Record, 0, len(fields))
+	for _, field := range fields {
+		records = append(records, hashRecord(strings.TrimPrefix(key, options.Table), field, values[field]))
+	}
+	return records, nil
+}
+
+func hashRecord(key, field, value string) *store.Record {
+	return &store.Record{
+		Key:      key,
+		Value:    []byte(value),
+		Metadata: map[string]interface{}{"field": field},
+	}
+}
+
+// record.Expiry 大于 0 时设置整个哈希的过期时间
+func (r *rkv) writeHashField(key string, record *store.Record, field string, options store.WriteOptions) error {
+	_, err := r.Client.TxPipelined(options.Context, func(p redis.Pipeliner) error {
+		p.HSet(options.Context, key, field, record.Value)
+		if record.Expiry > 0 {
+			p.Expire(options.Context, key, record.Expiry)
+		}
+		return nil
+	})
+	return err
+}
+
+// 与 WriteIncrBy 相同，哈希没有过期时间时才设置
+func (r *rkv) incrHashField(key string, record *store.Record, incr *incrHField, options store.WriteOptions) error {
+	v, err := incrScript.Run(options.Context, r.Client, []string{key}, record.Expiry.Milliseconds(), "HINCRBY", incr.Field, incr.Delta).Int64()
+	if nil != err {
+		return err
+	}
+	if nil == record.Metadata {
+		record.Metadata = make(map[string]interface{})
+	}
+	record.Metadata["field"] = incr.Field
+	record.Metadata["value"] = v
+	return nil
+}
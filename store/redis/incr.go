@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/micro/micro/v3/service/store"
+)
+
+// incrScript 增加 KEYS[1]，ARGV: 过期毫秒数、命令、命令的其余参数
+// 键没有过期时间时才设置，计数器从创建起计时
+var incrScript = redis.NewScript(`
+local v = redis.call(ARGV[2], KEYS[1], unpack(ARGV, 3))
+if tonumber(ARGV[1]) > 0 and redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return v
+`)
+
+func (r *rkv) incrBy(key string, record *store.Record, cmd string, delta interface{}, options store.WriteOptions) error {
+	v, err := incrScript.Run(options.Context, r.Client, []string{key}, record.Expiry.Milliseconds(), cmd, delta).Result()
+	if nil != err {
+		return err
+	}
+	var value interface{}
+	switch v := v.(type) {
+	case int64:
+		value = v
+	case string:
+		// INCRBYFLOAT 返回字符串
+		f, err := strconv.ParseFloat(v, 64)
+		if nil != err {
+			return err
+		}
+		value = f
+	}
+	if nil == record.Metadata {
+		record.Metadata = make(map[string]interface{})
+	}
+	record.Metadata["value"] = value
+	return nil
+}
+
+func (r *rkv) incrSortedSet(key string, record *store.Record, delta float64, options store.WriteOptions) error {
+	score, err := r.Client.ZIncrBy(options.Context, key, delta, string(record.Value)).Result()
+	if nil != err {
+		return err
+	}
+	if nil == record.Metadata {
+		record.Metadata = make(map[string]interface{})
+	}
+	record.Metadata["score"] = score
+	return nil
+}
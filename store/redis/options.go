@@ -74,3 +74,104 @@ func DeleteZMember(member string) store.DeleteOption {
 		r.Context = context.WithValue(r.Context, deleteZMemberKey{}, member)
 	}
 }
+
+type writeHFieldKey struct{}
+
+// 写入哈希的字段，值为 record.Value
+func WriteHField(field string) store.WriteOption {
+	return func(w *store.WriteOptions) {
+		if nil == w.Context {
+			w.Context = context.Background()
+		}
+		w.Context = context.WithValue(w.Context, writeHFieldKey{}, field)
+	}
+}
+
+type incrHField struct {
+	Field string
+	Delta int64
+}
+type incrHFieldKey struct{}
+
+// 哈希字段原子增加 delta，新值写入 record.Metadata["value"]
+func IncrHField(field string, delta int64) store.WriteOption {
+	return func(w *store.WriteOptions) {
+		if nil == w.Context {
+			w.Context = context.Background()
+		}
+		w.Context = context.WithValue(w.Context, incrHFieldKey{}, &incrHField{Field: field, Delta: delta})
+	}
+}
+
+type readHFieldsKey struct{}
+
+// 读取哈希的指定字段，不存在的字段不返回
+func ReadHFields(fields ...string) store.ReadOption {
+	return func(r *store.ReadOptions) {
+		if nil == r.Context {
+			r.Context = context.Background()
+		}
+		r.Context = context.WithValue(r.Context, readHFieldsKey{}, fields)
+	}
+}
+
+type readHAllKey struct{}
+
+// 读取哈希的所有字段，按字段名排序
+func ReadHAll() store.ReadOption {
+	return func(r *store.ReadOptions) {
+		if nil == r.Context {
+			r.Context = context.Background()
+		}
+		r.Context = context.WithValue(r.Context, readHAllKey{}, true)
+	}
+}
+
+type deleteHFieldKey struct{}
+
+// 删除哈希的字段
+func DeleteHField(fields ...string) store.DeleteOption {
+	return func(r *store.DeleteOptions) {
+		if nil == r.Context {
+			r.Context = context.Background()
+		}
+		r.Context = context.WithValue(r.Context, deleteHFieldKey{}, fields)
+	}
+}
+
+type writeIncrByKey struct{}
+
+// 整数原子增加 delta，新值写入 record.Metadata["value"]
+// record.Expiry 大于 0 时，键没有过期时间则设置
+func WriteIncrBy(delta int64) store.WriteOption {
+	return func(w *store.WriteOptions) {
+		if nil == w.Context {
+			w.Context = context.Background()
+		}
+		w.Context = context.WithValue(w.Context, writeIncrByKey{}, delta)
+	}
+}
+
+type writeIncrByFloatKey struct{}
+
+// 浮点数原子增加 delta，同 WriteIncrBy
+func WriteIncrByFloat(delta float64) store.WriteOption {
+	return func(w *store.WriteOptions) {
+		if nil == w.Context {
+			w.Context = context.Background()
+		}
+		w.Context = context.WithValue(w.Context, writeIncrByFloatKey{}, delta)
+	}
+}
+
+type writeZIncrByKey struct{}
+
+// 成员 record.Value 的分数原子增加 delta，新分数写入 record.Metadata["score"]
+func WriteZIncrBy(delta float64) store.WriteOption {
+	return func(w *store.WriteOptions) {
+		if nil == w.Context {
+			w.Context = context.Background()
+		}
+		w.Context = context.WithValue(w.Context, writeZIncrByKey{}, delta)
+	}
+}
@@ -3,7 +3,11 @@ package redis
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/go-redis/redis/v8"
 	log "github.com/micro/micro/v3/service/logger"
@@ -12,7 +16,9 @@ import (
 
 type rkv struct {
 	options store.Options
-	Client  *redis.Client
+	// Client is a *redis.Client, a sentinel backed *redis.Client or a
+	// *redis.ClusterClient depending on the nodes
+	Client redis.UniversalClient
 }
 
 func (r *rkv) Init(opts ...store.Option) error {
@@ -38,18 +44,19 @@ func (r *rkv) Read(key string, opts ...store.ReadOption) ([]*store.Record, error
 	var keys []string
 
 	rkey := fmt.Sprintf("%s%s", options.Table, key)
-	// Handle Prefix
-	// TODO suffix
-	if options.Prefix {
-		prefixKey := fmt.Sprintf("%s*", rkey)
-		fkeys, err := r.Client.Keys(options.Context, prefixKey).Result()
+	if options.Prefix || options.Suffix {
+		prefix, suffix := options.Table, key
+		if options.Prefix {
+			prefix = rkey
+		}
+		if !options.Suffix {
+			suffix = ""
+		}
+		fkeys, err := r.scan(options.Context, prefix, suffix)
 		if err != nil {
 			return nil, err
 		}
-		// TODO Limit Offset
-
-		keys = append(keys, fkeys...)
-
+		keys = page(fkeys, options.Offset, options.Limit)
 	} else {
 		keys = []string{rkey}
 	}
@@ -64,35 +71,102 @@ func (r *rkv) Read(key string, opts ...store.ReadOption) ([]*store.Record, error
 		return r.rangeByIndex(rkey, v.(*readZRangeByIndex), &options)
 	} else if v := options.Context.Value(readZRangeWithScoreKey{}); nil != v {
 		return r.rangeByScore(rkey, v.(*redis.ZRangeBy), &options)
+	} else if v := options.Context.Value(readHFieldsKey{}); nil != v {
+		return r.readHashFields(rkey, v.([]string), &options)
+	} else if v := options.Context.Value(readHAllKey{}); nil != v {
+		return r.readHashAll(rkey, &options)
 	}
 
-	records := make([]*store.Record, 0, len(keys))
-	for _, rkey = range keys {
-		val, err := r.Client.Get(options.Context, rkey).Bytes()
+	records, err := r.readKeys(options.Context, keys, options.Table)
+	if err != nil {
+		return nil, err
+	}
+	// a single key must exist, the keys of a prefix read may have expired
+	// since the scan
+	if !options.Prefix && !options.Suffix && len(records) == 0 {
+		return nil, store.ErrNotFound
+	}
 
-		if err != nil && err == redis.Nil {
-			return nil, store.ErrNotFound
-		} else if err != nil {
-			return nil, err
-		}
+	return records, nil
+}
 
-		if val == nil {
-			return nil, store.ErrNotFound
-		}
+// readBatch is the number of keys read per pipeline
+const readBatch = 500
 
-		d, err := r.Client.TTL(options.Context, rkey).Result()
-		if err != nil {
+// readKeys reads the values and the expiries of keys with one pipeline per
+// readBatch keys, the keys which don't exist are skipped
+func (r *rkv) readKeys(ctx context.Context, keys []string, table string) ([]*store.Record, error) {
+	records := make([]*store.Record, 0, len(keys))
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > readBatch {
+			batch = batch[:readBatch]
+		}
+		keys = keys[len(batch):]
+
+		gets := make([]*redis.StringCmd, len(batch))
+		ttls := make([]*redis.DurationCmd, len(batch))
+		_, err := r.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+			for i, k := range batch {
+				gets[i] = p.Get(ctx, k)
+				ttls[i] = p.PTTL(ctx, k)
+			}
+			return nil
+		})
+		if err != nil && err != redis.Nil {
 			return nil, err
 		}
+		for i, k := range batch {
+			val, err := gets[i].Bytes()
+			if err == redis.Nil {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			// -1 means no expiry and -2 that the key expired after the GET
+			d := ttls[i].Val()
+			if d < 0 {
+				d = 0
+			}
+			records = append(records, &store.Record{
+				Key:    strings.TrimPrefix(k, table),
+				Value:  val,
+				Expiry: d,
+			})
+		}
+	}
+	return records, nil
+}
 
-		records = append(records, &store.Record{
-			Key:    key,
-			Value:  val,
-			Expiry: d,
-		})
+// ReadMulti reads keys of the table of opts in a few round trips when s is a
+// redis store, the keys which don't exist are skipped. Other stores read
+// the keys one at a time.
+func ReadMulti(s store.Store, keys []string, opts ...store.ReadOption) ([]*store.Record, error) {
+	r, ok := s.(*rkv)
+	if !ok {
+		records := make([]*store.Record, 0, len(keys))
+		for _, k := range keys {
+			recs, err := s.Read(k, opts...)
+			if err == store.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			records = append(records, recs...)
+		}
+		return records, nil
 	}
 
-	return records, nil
+	options := store.ReadOptions{Context: context.Background()}
+	options.Table = r.options.Table
+	for _, o := range opts {
+		o(&options)
+	}
+	rkeys := make([]string, len(keys))
+	for i, k := range keys {
+		rkeys[i] = options.Table + k
+	}
+	return r.readKeys(options.Context, rkeys, options.Table)
 }
 
 func (r *rkv) rangeByIndex(key string, rangeBy *readZRangeByIndex, options *store.ReadOptions) ([]*store.Record, error) {
@@ -200,6 +274,8 @@ func (r *rkv) Delete(key string, opts ...store.DeleteOption) error {
 
 	if v := options.Context.Value(deleteZMemberKey{}); nil != v {
 		return r.deleteSortedSetMember(rkey, v.(string), options)
+	} else if v := options.Context.Value(deleteHFieldKey{}); nil != v {
+		return r.Client.HDel(options.Context, rkey, v.([]string)...).Err()
 	}
 
 	return r.Client.Del(options.Context, rkey).Err()
@@ -226,6 +302,16 @@ func (r *rkv) Write(record *store.Record, opts ...store.WriteOption) error {
 	rkey := fmt.Sprintf("%s%s", options.Table, record.Key)
 	if v := options.Context.Value(writeZScoreKey{}); v != nil {
 		return r.writeSortedSet(rkey, record, v.(float64), options)
+	} else if v := options.Context.Value(writeHFieldKey{}); v != nil {
+		return r.writeHashField(rkey, record, v.(string), options)
+	} else if v := options.Context.Value(incrHFieldKey{}); v != nil {
+		return r.incrHashField(rkey, record, v.(*incrHField), options)
+	} else if v := options.Context.Value(writeIncrByKey{}); v != nil {
+		return r.incrBy(rkey, record, "INCRBY", v.(int64), options)
+	} else if v := options.Context.Value(writeIncrByFloatKey{}); v != nil {
+		return r.incrBy(rkey, record, "INCRBYFLOAT", v.(float64), options)
+	} else if v := options.Context.Value(writeZIncrByKey{}); v != nil {
+		return r.incrSortedSet(rkey, record, v.(float64), options)
 	}
 	return r.Client.Set(options.Context, rkey, record.Value, record.Expiry).Err()
 }
@@ -238,10 +324,14 @@ func (r *rkv) List(opts ...store.ListOption) ([]string, error) {
 		o(&options)
 	}
 
-	keys, err := r.Client.Keys(options.Context, "*").Result()
+	keys, err := r.scan(options.Context, options.Table+options.Prefix, options.Suffix)
 	if err != nil {
 		return nil, err
 	}
+	keys = page(keys, options.Offset, options.Limit)
+	for i, k := range keys {
+		keys[i] = strings.TrimPrefix(k, options.Table)
+	}
 
 	return keys, nil
 }
@@ -270,14 +360,35 @@ func NewStore(opts ...store.Option) store.Store {
 	return s
 }
 
+// configure picks the client from the nodes:
+//
+//	redis-sentinel://[user:pass@]host:port,host:port[/db]?master=name[&sentinel_password=pass]
+//	redis-cluster://[user:pass@]host:port,host:port, or more than one node
+//	redis://[user:pass@]host:port[/db] or host:port for a single server
 func (r *rkv) configure() error {
-	var redisOptions *redis.Options
 	nodes := r.options.Nodes
 
 	if len(nodes) == 0 {
 		nodes = []string{"redis://127.0.0.1:6379"}
 	}
 
+	switch {
+	case strings.HasPrefix(nodes[0], "redis-sentinel://"):
+		failoverOptions, err := parseSentinelURL(nodes[0])
+		if err != nil {
+			return err
+		}
+		r.Client = redis.NewFailoverClient(failoverOptions)
+		return nil
+	case strings.HasPrefix(nodes[0], "redis-cluster://") || len(nodes) > 1:
+		clusterOptions, err := parseClusterNodes(nodes)
+		if err != nil {
+			return err
+		}
+		r.Client = redis.NewClusterClient(clusterOptions)
+		return nil
+	}
+
 	redisOptions, err := redis.ParseURL(nodes[0])
 	if err != nil {
 		//Backwards compatibility
@@ -291,3 +402,128 @@ func (r *rkv) configure() error {
 	r.Client = redis.NewClient(redisOptions)
 	return nil
 }
+
+func parseSentinelURL(node string) (*redis.FailoverOptions, error) {
+	u, err := url.Parse(node)
+	if err != nil {
+		return nil, err
+	}
+	options := &redis.FailoverOptions{
+		MasterName:       u.Query().Get("master"),
+		SentinelAddrs:    strings.Split(u.Host, ","),
+		SentinelPassword: u.Query().Get("sentinel_password"),
+	}
+	if len(options.MasterName) == 0 {
+		return nil, fmt.Errorf("redis: no master name in %s", node)
+	}
+	if u.User != nil {
+		options.Username = u.User.Username()
+		options.Password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); len(db) > 0 {
+		if options.DB, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("redis: invalid database number %q", db)
+		}
+	}
+	return options, nil
+}
+
+// parseClusterNodes accepts a redis-cluster:// url listing the seed nodes,
+// or one node per url, the credentials are the ones of the first node
+func parseClusterNodes(nodes []string) (*redis.ClusterOptions, error) {
+	options := new(redis.ClusterOptions)
+	for i, node := range nodes {
+		if strings.HasPrefix(node, "redis-cluster://") {
+			u, err := url.Parse(node)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 && u.User != nil {
+				options.Username = u.User.Username()
+				options.Password, _ = u.User.Password()
+			}
+			options.Addrs = append(options.Addrs, strings.Split(u.Host, ",")...)
+			continue
+		}
+		redisOptions, err := redis.ParseURL(node)
+		if err != nil {
+			options.Addrs = append(options.Addrs, node)
+			continue
+		}
+		if i == 0 {
+			options.Username, options.Password = redisOptions.Username, redisOptions.Password
+		}
+		options.Addrs = append(options.Addrs, redisOptions.Addr)
+	}
+	return options, nil
+}
+
+// scanCount is the COUNT hint of the SCAN iterations
+const scanCount = 1000
+
+// scan returns the sorted keys starting with prefix and ending with suffix,
+// iterating with SCAN on every master of a cluster
+func (r *rkv) scan(ctx context.Context, prefix, suffix string) ([]string, error) {
+	pattern := escapeGlob(prefix) + "*" + escapeGlob(suffix)
+	var mtx sync.Mutex
+	var keys []string
+	scan := func(ctx context.Context, c redis.UniversalClient) error {
+		iter := c.Scan(ctx, 0, pattern, scanCount).Iterator()
+		for iter.Next(ctx) {
+			mtx.Lock()
+			keys = append(keys, iter.Val())
+			mtx.Unlock()
+		}
+		return iter.Err()
+	}
+
+	var err error
+	if c, ok := r.Client.(*redis.ClusterClient); ok {
+		err = c.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			return scan(ctx, master)
+		})
+	} else {
+		err = scan(ctx, r.Client)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// SCAN may return a key more than once
+	sort.Strings(keys)
+	return dedup(keys), nil
+}
+
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func dedup(keys []string) []string {
+	out := keys[:0]
+	for i, k := range keys {
+		if i > 0 && k == keys[i-1] {
+			continue
+		}
+		out = append(out, k)
+	}
+	return out
+}
+
+// page applies Offset and Limit to sorted keys, no limit when 0
+func page(keys []string, offset, limit uint) []string {
+	if offset >= uint(len(keys)) {
+		return nil
+	}
+	keys = keys[offset:]
+	if limit > 0 && limit < uint(len(keys)) {
+		keys = keys[:limit]
+	}
+	return keys
+}
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"github.com/go-redis/redis/v8"
 	"github.com/micro/micro/v3/service/store"
 	"math/rand"
@@ -69,20 +70,112 @@ func Test_rkv_configure(t *testing.T) {
 				t.Errorf("configure() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if r.Client.Options().Addr != tt.want.address {
-				t.Errorf("configure() Address = %v, want address %v", r.Client.Options().Addr, tt.want.address)
+			client := r.Client.(*redis.Client)
+			if client.Options().Addr != tt.want.address {
+				t.Errorf("configure() Address = %v, want address %v", client.Options().Addr, tt.want.address)
 			}
-			if r.Client.Options().Password != tt.want.password {
-				t.Errorf("configure() password = %v, want password %v", r.Client.Options().Password, tt.want.password)
+			if client.Options().Password != tt.want.password {
+				t.Errorf("configure() password = %v, want password %v", client.Options().Password, tt.want.password)
 			}
-			if r.Client.Options().Username != tt.want.username {
-				t.Errorf("configure() username = %v, want username %v", r.Client.Options().Username, tt.want.username)
+			if client.Options().Username != tt.want.username {
+				t.Errorf("configure() username = %v, want username %v", client.Options().Username, tt.want.username)
 			}
 
 		})
 	}
 }
 
+func Test_rkv_configureTopology(t *testing.T) {
+	r := &rkv{options: store.Options{Nodes: []string{"redis-sentinel://:password@s1:26379,s2:26379/2?master=mymaster"}}}
+	if err := r.configure(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Client.(*redis.Client); !ok {
+		t.Errorf("configure() sentinel client = %T, want *redis.Client", r.Client)
+	}
+	failover, err := parseSentinelURL(r.options.Nodes[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failover.MasterName != "mymaster" || len(failover.SentinelAddrs) != 2 || failover.Password != "password" || failover.DB != 2 {
+		t.Errorf("parseSentinelURL() = %+v", failover)
+	}
+	if _, err := parseSentinelURL("redis-sentinel://s1:26379"); err == nil {
+		t.Errorf("parseSentinelURL() without master, want an error")
+	}
+
+	for _, nodes := range [][]string{
+		{"redis://:password@n1:6379", "n2:6379", "redis://n3:6379"},
+		{"redis-cluster://:password@n1:6379,n2:6379,n3:6379"},
+	} {
+		r := &rkv{options: store.Options{Nodes: nodes}}
+		if err := r.configure(); err != nil {
+			t.Fatal(err)
+		}
+		c, ok := r.Client.(*redis.ClusterClient)
+		if !ok {
+			t.Fatalf("configure(%v) client = %T, want *redis.ClusterClient", nodes, r.Client)
+		}
+		if opts := c.Options(); len(opts.Addrs) != 3 || opts.Addrs[1] != "n2:6379" || opts.Password != "password" {
+			t.Errorf("configure(%v) cluster options = %v, %q", nodes, opts.Addrs, opts.Password)
+		}
+	}
+}
+
+func Test_page(t *testing.T) {
+	keys := dedup([]string{"a", "b", "b", "c", "d"})
+	if len(keys) != 4 {
+		t.Fatalf("dedup() = %v", keys)
+	}
+	if got := page(keys, 1, 2); len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("page(1, 2) = %v, want [b c]", got)
+	}
+	if got := page(keys, 3, 0); len(got) != 1 || got[0] != "d" {
+		t.Errorf("page(3, 0) = %v, want [d]", got)
+	}
+	if got := page(keys, 4, 1); len(got) != 0 {
+		t.Errorf("page(4, 1) = %v, want []", got)
+	}
+	if got := escapeGlob("user:*[1]"); got != `user:\*\[1\]` {
+		t.Errorf("escapeGlob() = %s", got)
+	}
+}
+
+// mapStore is a store.Store reading from a map
+type mapStore struct {
+	store.Store
+	values map[string][]byte
+}
+
+func (m *mapStore) Read(key string, opts ...store.ReadOption) ([]*store.Record, error) {
+	v, ok := m.values[key]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return []*store.Record{{Key: key, Value: v}}, nil
+}
+
+func (m *mapStore) Write(r *store.Record, opts ...store.WriteOption) error {
+	m.values[r.Key] = r.Value
+	return nil
+}
+
+func Test_ReadMultiFallback(t *testing.T) {
+	s := &mapStore{values: make(map[string][]byte)}
+	for _, k := range []string{"p1", "p2"} {
+		if err := s.Write(&store.Record{Key: k, Value: []byte(k)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	records, err := ReadMulti(s, []string{"p1", "gone", "p2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Key != "p1" || records[1].Key != "p2" {
+		t.Errorf("ReadMulti() = %v", records)
+	}
+}
+
 func Test_Store(t *testing.T) {
 	if tr := os.Getenv("TRAVIS"); len(tr) > 0 {
 		t.Skip()
@@ -207,3 +300,126 @@ func Test_ZSet(t *testing.T) {
 	}
 
 }
+
+func Test_Hash(t *testing.T) {
+	if tr := os.Getenv("TRAVIS"); len(tr) > 0 {
+		t.Skip()
+	}
+	r := new(rkv)
+	r.options = store.Options{Nodes: []string{"redis://127.0.0.1:6379"}}
+
+	if err := r.configure(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	key := "myHash"
+	if err := r.Write(&store.Record{Key: key, Value: []byte("alice")}, WriteHField("name")); err != nil {
+		t.Errorf("Hash Write Error. Error: %v", err)
+	}
+	rec := &store.Record{Key: key, Expiry: 2 * time.Minute}
+	for idx := 0; idx < 3; idx++ {
+		if err := r.Write(rec, IncrHField("level", 2)); err != nil {
+			t.Errorf("Hash Incr Error. Error: %v", err)
+		}
+	}
+	if v, _ := rec.Metadata["value"].(int64); v != 6 {
+		t.Errorf("Hash Incr Error. Metadata: %+v", rec.Metadata)
+	}
+	// 已有过期时间时不再延长
+	rec.Expiry = 10 * time.Minute
+	if err := r.Write(rec, IncrHField("level", 0)); err != nil {
+		t.Errorf("Hash Incr Error. Error: %v", err)
+	}
+	if d := r.Client.PTTL(context.Background(), key).Val(); d <= 0 || d > 2*time.Minute {
+		t.Errorf("Hash Incr Error. TTL: %v", d)
+	}
+
+	records, err := r.Read(key, ReadHFields("name", "missing"))
+	if err != nil {
+		t.Errorf("Hash Read Error. Error: %v", err)
+		return
+	}
+	if len(records) != 1 || string(records[0].Value) != "alice" || records[0].Metadata["field"] != "name" {
+		t.Errorf("Hash Read Error. Records: %+v", records)
+	}
+
+	if err := r.Delete(key, DeleteHField("name")); err != nil {
+		t.Errorf("Hash Delete Error. Error: %v", err)
+	}
+	records, err = r.Read(key, ReadHAll())
+	if err != nil {
+		t.Errorf("Hash Read Error. Error: %v", err)
+		return
+	}
+	if len(records) != 1 || string(records[0].Value) != "6" {
+		t.Errorf("Hash Read Error. Records: %+v", records)
+	}
+	r.Delete(key)
+
+	// 返回的 key 不带表前缀
+	if err := r.Write(&store.Record{Key: key, Value: []byte("bob")}, WriteHField("name"), store.WriteTo("", "tbl:")); err != nil {
+		t.Errorf("Hash Write Error. Error: %v", err)
+	}
+	records, err = r.Read(key, ReadHAll(), store.ReadFrom("", "tbl:"))
+	if err != nil {
+		t.Errorf("Hash Read Error. Error: %v", err)
+		return
+	}
+	if len(records) != 1 || records[0].Key != key {
+		t.Errorf("Hash Read Error. Records: %+v", records)
+	}
+	r.Delete(key, store.DeleteFrom("", "tbl:"))
+}
+
+func Test_Incr(t *testing.T) {
+	if tr := os.Getenv("TRAVIS"); len(tr) > 0 {
+		t.Skip()
+	}
+	r := new(rkv)
+	r.options = store.Options{Nodes: []string{"redis://127.0.0.1:6379"}}
+
+	if err := r.configure(); err != nil {
+		t.Error(err)
+		return
+	}
+
+	key := "myCounter"
+	r.Delete(key)
+	rec := &store.Record{Key: key, Expiry: 2 * time.Minute}
+	for idx := 0; idx < 3; idx++ {
+		if err := r.Write(rec, WriteIncrBy(5)); err != nil {
+			t.Errorf("Incr Error. Error: %v", err)
+		}
+	}
+	if v, _ := rec.Metadata["value"].(int64); v != 15 {
+		t.Errorf("Incr Error. Metadata: %+v", rec.Metadata)
+	}
+	if err := r.Write(rec, WriteIncrByFloat(0.5)); err != nil {
+		t.Errorf("IncrByFloat Error. Error: %v", err)
+	}
+	if v, _ := rec.Metadata["value"].(float64); v != 15.5 {
+		t.Errorf("IncrByFloat Error. Metadata: %+v", rec.Metadata)
+	}
+	records, err := r.Read(key)
+	if err != nil {
+		t.Errorf("Incr Read Error. Error: %v", err)
+		return
+	}
+	if records[0].Expiry <= 0 || records[0].Expiry > 2*time.Minute {
+		t.Errorf("Incr Expiry Error. Expiry: %v", records[0].Expiry)
+	}
+	r.Delete(key)
+
+	key = "myBoard"
+	rec = &store.Record{Key: key, Value: []byte("alice")}
+	for idx := 0; idx < 2; idx++ {
+		if err := r.Write(rec, WriteZIncrBy(1.5)); err != nil {
+			t.Errorf("ZIncr Error. Error: %v", err)
+		}
+	}
+	if v, _ := rec.Metadata["score"].(float64); v != 3 {
+		t.Errorf("ZIncr Error. Metadata: %+v", rec.Metadata)
+	}
+	r.Delete(key)
+}
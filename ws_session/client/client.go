@@ -1,23 +1,53 @@
 package client
 
 import (
+	"context"
+	"sync"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/micro/micro/v3/service/client"
-	"github.com/micro/micro/v3/service/context"
+	mcontext "github.com/micro/micro/v3/service/context"
 	"github.com/micro/micro/v3/service/logger"
 	"github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
 	pb "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/proto"
 )
 
 type srv struct {
-	opts session.Options
+	opts    session.Options
 	session pb.SessionService
+
+	mtx    sync.RWMutex
+	status session.Status
 }
 
 func (s *srv) Init(opts ...session.Option) {
 	for _, o := range opts {
 		o(&s.opts)
 	}
+	s.configure()
+}
+func (s *srv) configure() {
+	if s.opts.Service != nil {
+		s.session = s.opts.Service
+		return
+	}
+	name := s.opts.ServiceName
+	if len(name) == 0 {
+		name = "websocket"
+	}
+	c := s.opts.Client
+	if c == nil {
+		c = client.DefaultClient
+	}
+	s.session = pb.NewSessionService(name, c)
+}
+
+// callOptions routes a call to the server of the session after the default
+// options
+func (s *srv) callOptions() []client.CallOption {
+	opts := make([]client.CallOption, 0, len(s.opts.CallOptions)+2)
+	opts = append(opts, s.opts.CallOptions...)
+	return append(opts, client.WithServerUid(s.opts.ServerID), client.WithAuthToken())
 }
 func (s *srv) Options() session.Options {
 	return s.opts
@@ -28,16 +58,108 @@ func (s *srv) String() string {
 func (s *srv) SessionID() string {
 	return s.opts.SessionID
 }
+func (s *srv) context() context.Context {
+	if s.opts.Context != nil {
+		return s.opts.Context
+	}
+	return mcontext.DefaultContext
+}
+func (s *srv) codec() session.Marshaler {
+	if s.opts.Codec != nil {
+		return s.opts.Codec
+	}
+	return protoMarshaler{}
+}
 func (s *srv) Bind(status map[string]string) error {
-	_, err := s.session.Bind(context.DefaultContext, &pb.SessionStatus{Sid: s.opts.SessionID, Status: status}, client.WithServerUid(s.opts.ServerID), client.WithAuthToken())
+	return s.BindCtx(s.context(), status)
+}
+func (s *srv) BindCtx(ctx context.Context, status map[string]string) error {
+	_, err := s.session.Bind(ctx, &pb.SessionStatus{Sid: s.opts.SessionID, Status: status}, s.callOptions()...)
 	if err != nil {
 		logger.Infof("session bind status, err:%v", err)
+		return err
 	}
-	return err
+	s.setStatus(session.Status(status).Copy())
+	return nil
+}
+func (s *srv) SetStatus(ctx context.Context, status map[string]interface{}) error {
+	set := make(map[string]string, len(status))
+	for k, v := range status {
+		str, err := session.FormatStatus(v)
+		if err != nil {
+			return err
+		}
+		set[k] = str
+	}
+	return s.patchStatus(ctx, &pb.StatusPatch{Sid: s.opts.SessionID, Set: set})
+}
+func (s *srv) DeleteStatus(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.patchStatus(ctx, &pb.StatusPatch{Sid: s.opts.SessionID, Delete: keys})
+}
+func (s *srv) patchStatus(ctx context.Context, req *pb.StatusPatch) error {
+	rsp, err := s.session.PatchStatus(ctx, req, s.callOptions()...)
+	if err != nil {
+		logger.Infof("session patch status, err:%v", err)
+		return err
+	}
+	s.setStatus(session.Status(rsp.Status).Copy())
+	return nil
+}
+func (s *srv) GetStatus(ctx context.Context, keys ...string) (session.Status, error) {
+	rsp, err := s.session.GetStatus(ctx, &pb.StatusRequest{Sid: s.opts.SessionID, Keys: keys}, s.callOptions()...)
+	if err != nil {
+		logger.Infof("session get status, err:%v", err)
+		return nil, err
+	}
+	status := session.Status(rsp.Status).Copy()
+	if len(keys) == 0 {
+		s.setStatus(status.Copy())
+		return status, nil
+	}
+	// only the requested keys are refreshed, the missing ones are gone
+	s.mtx.Lock()
+	for _, k := range keys {
+		if v, ok := status[k]; ok {
+			s.status[k] = v
+		} else {
+			delete(s.status, k)
+		}
+	}
+	s.mtx.Unlock()
+	return status, nil
+}
+func (s *srv) Status() session.Status {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.status.Copy()
+}
+func (s *srv) setStatus(status session.Status) {
+	s.mtx.Lock()
+	s.status = status
+	s.mtx.Unlock()
 }
 
 func (s *srv) Kick() error {
-	_, err := s.session.Kick(context.DefaultContext, &pb.KickRequest{Sid: s.opts.SessionID}, client.WithServerUid(s.opts.ServerID), client.WithAuthToken())
+	return s.KickCtx(s.context())
+}
+func (s *srv) KickCtx(ctx context.Context) error {
+	return s.KickWith(ctx)
+}
+func (s *srv) KickWith(ctx context.Context, opts ...session.KickOption) error {
+	var options session.KickOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	req := &pb.KickRequest{
+		Sid:       s.opts.SessionID,
+		Reason:    options.Reason,
+		Message:   options.Message,
+		CloseCode: options.CloseCode,
+	}
+	_, err := s.session.Kick(ctx, req, s.callOptions()...)
 	if err != nil {
 		logger.Infof("session kick, err:%v", err)
 	}
@@ -45,7 +167,10 @@ func (s *srv) Kick() error {
 }
 
 func (s *srv) Send(route string, v interface{}) error {
-	b, err := proto.Marshal(v.(proto.Message))
+	return s.SendCtx(s.context(), route, v)
+}
+func (s *srv) SendCtx(ctx context.Context, route string, v interface{}) error {
+	b, err := s.codec().Marshal(v)
 	if err != nil {
 		return err
 	}
@@ -54,19 +179,39 @@ func (s *srv) Send(route string, v interface{}) error {
 		Route: route,
 		Body:  b,
 	}
-	_, err = s.session.Send(context.DefaultContext, req, client.WithServerUid(s.opts.ServerID), client.WithAuthToken())
+	_, err = s.session.Send(ctx, req, s.callOptions()...)
 	if err != nil {
 		logger.Infof("session send, err:%v", err)
 	}
 	return err
 }
 
-func NewSession(opts ...session.Option) session.Session {
-	s := &srv{
-		session: pb.NewSessionService("websocket", client.DefaultClient),
+type protoMarshaler struct{}
+
+func (protoMarshaler) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, session.ErrInvalidMessage
 	}
+	return proto.Marshal(m)
+}
+func (protoMarshaler) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return session.ErrInvalidMessage
+	}
+	return proto.Unmarshal(data, m)
+}
+func (protoMarshaler) String() string {
+	return "proto"
+}
+
+func NewSession(opts ...session.Option) session.Session {
+	s := new(srv)
 	for _, o := range opts {
 		o(&s.opts)
 	}
+	s.configure()
+	s.status = s.opts.Status.Copy()
 	return s
 }
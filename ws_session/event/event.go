@@ -0,0 +1,107 @@
+// Package event publishes and consumes the close event of the websocket
+// sessions over the micro broker.
+//
+// Topic, the message body is the protobuf encoded event:
+//
+//	micro.ws.session.close  pb.SessionClose  a websocket session was closed
+//
+// The websocket service publishes it, backends subscribe with a Subscriber:
+//
+//	sub := event.NewSubscriber(event.Queue("room"))
+//	sub.OnSessionClose(func(ev *pb.SessionClose) error {
+//		return rooms.Leave(ev.SessionId)
+//	})
+package event
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/logger"
+	pb "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/proto"
+	"sync"
+)
+
+const (
+	TopicClose = "micro.ws.session.close"
+)
+
+type Subscriber struct {
+	opts Options
+
+	mtx  sync.Mutex
+	subs []broker.Subscriber
+}
+
+func NewSubscriber(opts ...Option) *Subscriber {
+	var options Options
+	for _, o := range opts {
+		o(&options)
+	}
+	return &Subscriber{opts: options}
+}
+
+func (s *Subscriber) Options() Options {
+	return s.opts
+}
+
+func (s *Subscriber) OnSessionClose(fn func(ev *pb.SessionClose) error) error {
+	var opts []broker.SubscribeOption
+	if len(s.opts.Queue) > 0 {
+		opts = append(opts, broker.Queue(s.opts.Queue))
+	}
+	sub, err := getBroker(s.opts).Subscribe(TopicClose, func(m *broker.Message) error {
+		ev := new(pb.SessionClose)
+		if err := proto.Unmarshal(m.Body, ev); err != nil {
+			logger.Errorf("session event %s, err:%v", TopicClose, err)
+			return err
+		}
+		if err := fn(ev); err != nil {
+			logger.Errorf("session event %s, err:%v", TopicClose, err)
+			return err
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	s.subs = append(s.subs, sub)
+	s.mtx.Unlock()
+	return nil
+}
+
+// Close unsubscribes every callback
+func (s *Subscriber) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var err error
+	for _, sub := range s.subs {
+		if e := sub.Unsubscribe(); e != nil {
+			err = e
+		}
+	}
+	s.subs = nil
+	return err
+}
+
+func PublishClose(ev *pb.SessionClose, opts ...Option) error {
+	var options Options
+	for _, o := range opts {
+		o(&options)
+	}
+	b, err := proto.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return getBroker(options).Publish(TopicClose, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/protobuf"},
+		Body:   b,
+	})
+}
+
+func getBroker(opts Options) broker.Broker {
+	if opts.Broker != nil {
+		return opts.Broker
+	}
+	return broker.DefaultBroker
+}
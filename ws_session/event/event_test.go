@@ -0,0 +1,46 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/micro/micro/v3/service/broker"
+	"github.com/micro/micro/v3/service/broker/memory"
+	pb "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/proto"
+)
+
+func TestSessionClose(t *testing.T) {
+	b := memory.NewBroker()
+	if err := b.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	sub := NewSubscriber(Broker(b))
+
+	// the topic is the one documented for the websocket service
+	var raw int
+	s, err := b.Subscribe("micro.ws.session.close", func(m *broker.Message) error {
+		raw++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Unsubscribe()
+
+	var closed *pb.SessionClose
+	if err := sub.OnSessionClose(func(ev *pb.SessionClose) error { closed = ev; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := PublishClose(&pb.SessionClose{ServerId: "ws-1", SessionId: "s1"}, Broker(b)); err != nil {
+		t.Fatal(err)
+	}
+	if raw != 1 {
+		t.Errorf("%d events on %s, want 1", raw, TopicClose)
+	}
+	if closed == nil || closed.ServerId != "ws-1" || closed.SessionId != "s1" {
+		t.Errorf("close event = %v", closed)
+	}
+
+	if err := sub.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
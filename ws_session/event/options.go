@@ -0,0 +1,23 @@
+package event
+
+import "github.com/micro/micro/v3/service/broker"
+
+type Options struct {
+	// Broker carries the events, broker.DefaultBroker when nil
+	Broker broker.Broker
+	// Queue shares the subscription between the instances of a service so
+	// each event is handled once, by default every instance receives it
+	Queue string
+}
+type Option func(o *Options)
+
+func Broker(b broker.Broker) Option {
+	return func(o *Options) {
+		o.Broker = b
+	}
+}
+func Queue(name string) Option {
+	return func(o *Options) {
+		o.Queue = name
+	}
+}
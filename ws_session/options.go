@@ -1,8 +1,32 @@
 package session
 
+import (
+	"context"
+
+	"github.com/micro/micro/v3/service/client"
+	pb "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/proto"
+)
+
 type Options struct {
 	SessionID string
-	ServerID string
+	ServerID  string
+	// Context is used by the calls that don't take a context
+	Context context.Context
+	// Codec encodes the messages, protobuf when nil
+	Codec Marshaler
+	// ServiceName is the service name of the websocket server, "websocket"
+	// when empty
+	ServiceName string
+	// Client calls the websocket service, client.DefaultClient when nil
+	Client client.Client
+	// CallOptions are added to every call, e.g. retries and timeouts
+	CallOptions []client.CallOption
+	// Service replaces the client of the websocket service, e.g. with the
+	// fake of ws_session/sessiontest, ServiceName and Client are then unused
+	Service pb.SessionService
+	// Status is the status known when the session is created, e.g. from the
+	// inbound metadata
+	Status Status
 }
 type Option func(o *Options)
 
@@ -16,3 +40,82 @@ func ServerID(id string) Option {
 		o.ServerID = id
 	}
 }
+
+// Context sets the context used by Send, Bind and Kick
+func Context(ctx context.Context) Option {
+	return func(o *Options) {
+		o.Context = ctx
+	}
+}
+
+// Codec sets the marshaler used to encode the messages
+func Codec(c Marshaler) Option {
+	return func(o *Options) {
+		o.Codec = c
+	}
+}
+
+// ServiceName sets the service name of the websocket server
+func ServiceName(name string) Option {
+	return func(o *Options) {
+		o.ServiceName = name
+	}
+}
+
+// Client sets the client used to call the websocket service
+func Client(c client.Client) Option {
+	return func(o *Options) {
+		o.Client = c
+	}
+}
+
+// CallOptions adds default options to every call to the websocket service
+func CallOptions(opts ...client.CallOption) Option {
+	return func(o *Options) {
+		o.CallOptions = append(o.CallOptions, opts...)
+	}
+}
+
+// Service sets the client used to reach the websocket service
+func Service(s pb.SessionService) Option {
+	return func(o *Options) {
+		o.Service = s
+	}
+}
+
+// InitialStatus sets the status known when the session is created
+func InitialStatus(st Status) Option {
+	return func(o *Options) {
+		o.Status = st
+	}
+}
+
+// KickOptions tell the client why it is kicked
+type KickOptions struct {
+	Reason  pb.KickReason
+	Message string
+	// CloseCode is the code of the websocket close frame, 1000 when zero
+	CloseCode uint32
+}
+type KickOption func(o *KickOptions)
+
+func KickReason(r pb.KickReason) KickOption {
+	return func(o *KickOptions) {
+		o.Reason = r
+	}
+}
+
+// KickMessage sets a text shown to the user, e.g. the end of a maintenance
+func KickMessage(msg string) KickOption {
+	return func(o *KickOptions) {
+		o.Message = msg
+	}
+}
+
+// CloseCode sets the code of the websocket close frame, e.g. 4000 and above
+// for application codes
+func CloseCode(code uint32) KickOption {
+	return func(o *KickOptions) {
+		o.CloseCode = code
+	}
+}
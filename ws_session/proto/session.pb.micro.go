@@ -45,6 +45,8 @@ type SessionService interface {
 	Send(ctx context.Context, in *Message, opts ...client.CallOption) (*EmptyResponse, error)
 	Kick(ctx context.Context, in *KickRequest, opts ...client.CallOption) (*EmptyResponse, error)
 	Bind(ctx context.Context, in *SessionStatus, opts ...client.CallOption) (*EmptyResponse, error)
+	GetStatus(ctx context.Context, in *StatusRequest, opts ...client.CallOption) (*SessionStatus, error)
+	PatchStatus(ctx context.Context, in *StatusPatch, opts ...client.CallOption) (*SessionStatus, error)
 }
 
 type sessionService struct {
@@ -89,12 +91,34 @@ func (c *sessionService) Bind(ctx context.Context, in *SessionStatus, opts ...cl
 	return out, nil
 }
 
+func (c *sessionService) GetStatus(ctx context.Context, in *StatusRequest, opts ...client.CallOption) (*SessionStatus, error) {
+	req := c.c.NewRequest(c.name, "Session.GetStatus", in)
+	out := new(SessionStatus)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionService) PatchStatus(ctx context.Context, in *StatusPatch, opts ...client.CallOption) (*SessionStatus, error) {
+	req := c.c.NewRequest(c.name, "Session.PatchStatus", in)
+	out := new(SessionStatus)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Session service
 
 type SessionHandler interface {
 	Send(context.Context, *Message, *EmptyResponse) error
 	Kick(context.Context, *KickRequest, *EmptyResponse) error
 	Bind(context.Context, *SessionStatus, *EmptyResponse) error
+	GetStatus(context.Context, *StatusRequest, *SessionStatus) error
+	PatchStatus(context.Context, *StatusPatch, *SessionStatus) error
 }
 
 func RegisterSessionHandler(s server.Server, hdlr SessionHandler, opts ...server.HandlerOption) error {
@@ -102,6 +126,8 @@ func RegisterSessionHandler(s server.Server, hdlr SessionHandler, opts ...server
 		Send(ctx context.Context, in *Message, out *EmptyResponse) error
 		Kick(ctx context.Context, in *KickRequest, out *EmptyResponse) error
 		Bind(ctx context.Context, in *SessionStatus, out *EmptyResponse) error
+		GetStatus(ctx context.Context, in *StatusRequest, out *SessionStatus) error
+		PatchStatus(ctx context.Context, in *StatusPatch, out *SessionStatus) error
 	}
 	type Session struct {
 		session
@@ -125,3 +151,11 @@ func (h *sessionHandler) Kick(ctx context.Context, in *KickRequest, out *EmptyRe
 func (h *sessionHandler) Bind(ctx context.Context, in *SessionStatus, out *EmptyResponse) error {
 	return h.SessionHandler.Bind(ctx, in, out)
 }
+
+func (h *sessionHandler) GetStatus(ctx context.Context, in *StatusRequest, out *SessionStatus) error {
+	return h.SessionHandler.GetStatus(ctx, in, out)
+}
+
+func (h *sessionHandler) PatchStatus(ctx context.Context, in *StatusPatch, out *SessionStatus) error {
+	return h.SessionHandler.PatchStatus(ctx, in, out)
+}
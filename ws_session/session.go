@@ -9,14 +9,43 @@ var (
 	ErrIllegalUID          = errors.New("illegal uid")
 	ErrSessionAlreadyBound = errors.New("session is already bound to an uid")
 	ErrNoUIDBind           = errors.New("you have to bind an UID to the session to do that")
+	ErrInvalidMessage      = errors.New("message is not a proto.Message, set a Codec to send it")
 )
 
+// Marshaler encodes the messages sent to the websocket, the marshalers of the
+// session/codec packages can be used as is
+type Marshaler interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	String() string
+}
+
+// Session is a handle to a websocket connection. Every call has a Ctx variant
+// which carries the caller's deadline, cancellation and metadata to the
+// websocket service; the plain variants use Options().Context.
 type Session interface {
 	Init(opts ...Option)
 	Options() Options
 	Send(route string, v interface{}) error
+	SendCtx(ctx context.Context, route string, v interface{}) error
+	// Bind replaces the whole status, SetStatus merges into it
 	Bind(status map[string]string) error
+	BindCtx(ctx context.Context, status map[string]string) error
+	// SetStatus merges status into the one of the websocket server, values
+	// are encoded with FormatStatus
+	SetStatus(ctx context.Context, status map[string]interface{}) error
+	// GetStatus reads the given keys from the websocket server, all of them
+	// when none is given
+	GetStatus(ctx context.Context, keys ...string) (Status, error)
+	DeleteStatus(ctx context.Context, keys ...string) error
+	// Status is the last status known without a round trip: the one of the
+	// inbound metadata updated by the calls made on this session
+	Status() Status
 	Kick() error
+	KickCtx(ctx context.Context) error
+	// KickWith kicks the session telling the client why and closing the
+	// websocket with the given code
+	KickWith(ctx context.Context, opts ...KickOption) error
 	String() string
 }
 
@@ -0,0 +1,171 @@
+// Package sessiontest provides an in-process fake of the websocket service to
+// unit test handlers which use the session of their context.
+//
+//	func TestJoin(t *testing.T) {
+//		ws := sessiontest.NewService()
+//		ctx, _ := ws.NewContext(context.Background(), session.SessionID("s1"))
+//		if err := h.Join(ctx, req, rsp); err != nil {
+//			t.Fatal(err)
+//		}
+//		ws.ExpectSend(t, "s1", "room.onJoin")
+//	}
+package sessiontest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/micro/micro/v3/service/client"
+	"github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+	cli "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/client"
+	pb "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/proto"
+)
+
+var _ pb.SessionService = (*Service)(nil)
+
+// Service is a fake pb.SessionService recording every call it receives
+type Service struct {
+	mtx   sync.Mutex
+	sends []*pb.Message
+	binds []*pb.SessionStatus
+	kicks []*pb.KickRequest
+	// status of every session, as the websocket server would keep it
+	status map[string]session.Status
+}
+
+func NewService() *Service {
+	return &Service{status: make(map[string]session.Status)}
+}
+
+// NewSession returns a session whose calls go to the fake service
+func (s *Service) NewSession(opts ...session.Option) session.Session {
+	return cli.NewSession(append([]session.Option{session.ServerID("sessiontest"), session.Service(s)}, opts...)...)
+}
+
+// NewContext returns ctx holding a session of the fake service, the way
+// wrapper.SessionHandler sets it up for a request coming from the websocket
+func (s *Service) NewContext(ctx context.Context, opts ...session.Option) (context.Context, session.Session) {
+	ss := s.NewSession(append([]session.Option{session.Context(ctx)}, opts...)...)
+	return context.WithValue(ctx, session.SessionCtxKey{}, ss), ss
+}
+
+// Reset forgets the recorded calls
+func (s *Service) Reset() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.sends, s.binds, s.kicks = nil, nil, nil
+	s.status = make(map[string]session.Status)
+}
+
+// Status returns a copy of the status the fake keeps for sid
+func (s *Service) Status(sid string) session.Status {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.status[sid].Copy()
+}
+
+func (s *Service) Sends() []*pb.Message {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]*pb.Message(nil), s.sends...)
+}
+
+func (s *Service) Binds() []*pb.SessionStatus {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]*pb.SessionStatus(nil), s.binds...)
+}
+
+func (s *Service) Kicks() []*pb.KickRequest {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return append([]*pb.KickRequest(nil), s.kicks...)
+}
+
+// ExpectSend fails the test if nothing was sent to sid on route, it returns
+// the first matching message so its body can be checked
+func (s *Service) ExpectSend(t testing.TB, sid, route string) *pb.Message {
+	t.Helper()
+	for _, m := range s.Sends() {
+		if m.Sid == sid && m.Route == route {
+			return m
+		}
+	}
+	t.Errorf("sessiontest: nothing sent to %s on %s", sid, route)
+	return nil
+}
+
+func (s *Service) ExpectBind(t testing.TB, sid string) *pb.SessionStatus {
+	t.Helper()
+	for _, b := range s.Binds() {
+		if b.Sid == sid {
+			return b
+		}
+	}
+	t.Errorf("sessiontest: %s was not bound", sid)
+	return nil
+}
+
+func (s *Service) ExpectKick(t testing.TB, sid string) *pb.KickRequest {
+	t.Helper()
+	for _, k := range s.Kicks() {
+		if k.Sid == sid {
+			return k
+		}
+	}
+	t.Errorf("sessiontest: %s was not kicked", sid)
+	return nil
+}
+
+func (s *Service) Send(ctx context.Context, in *pb.Message, opts ...client.CallOption) (*pb.EmptyResponse, error) {
+	s.mtx.Lock()
+	s.sends = append(s.sends, in)
+	s.mtx.Unlock()
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *Service) Kick(ctx context.Context, in *pb.KickRequest, opts ...client.CallOption) (*pb.EmptyResponse, error) {
+	s.mtx.Lock()
+	s.kicks = append(s.kicks, in)
+	s.mtx.Unlock()
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *Service) Bind(ctx context.Context, in *pb.SessionStatus, opts ...client.CallOption) (*pb.EmptyResponse, error) {
+	s.mtx.Lock()
+	s.binds = append(s.binds, in)
+	s.status[in.Sid] = session.Status(in.Status).Copy()
+	s.mtx.Unlock()
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *Service) GetStatus(ctx context.Context, in *pb.StatusRequest, opts ...client.CallOption) (*pb.SessionStatus, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	status := s.status[in.Sid]
+	if len(in.Keys) == 0 {
+		return &pb.SessionStatus{Sid: in.Sid, Status: status.Copy()}, nil
+	}
+	rsp := &pb.SessionStatus{Sid: in.Sid, Status: make(map[string]string, len(in.Keys))}
+	for _, k := range in.Keys {
+		if v, ok := status[k]; ok {
+			rsp.Status[k] = v
+		}
+	}
+	return rsp, nil
+}
+
+func (s *Service) PatchStatus(ctx context.Context, in *pb.StatusPatch, opts ...client.CallOption) (*pb.SessionStatus, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	status := s.status[in.Sid].Copy()
+	for k, v := range in.Set {
+		status[k] = v
+	}
+	for _, k := range in.Delete {
+		delete(status, k)
+	}
+	s.status[in.Sid] = status
+	return &pb.SessionStatus{Sid: in.Sid, Status: status.Copy()}, nil
+}
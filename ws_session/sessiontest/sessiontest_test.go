@@ -0,0 +1,63 @@
+package sessiontest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
+)
+
+func TestStatus(t *testing.T) {
+	ws := NewService()
+	ctx, s := ws.NewContext(context.Background(), session.SessionID("s1"), session.InitialStatus(session.Status{"room": "r1"}))
+	if s.Status().GetString("room") != "r1" {
+		t.Fatalf("initial status %v", s.Status())
+	}
+	if err := s.Bind(map[string]string{"uid": "u1"}); err != nil {
+		t.Fatal(err)
+	}
+	type seat struct {
+		Row int `json:"row"`
+	}
+	if err := s.SetStatus(ctx, map[string]interface{}{"level": 3, "vip": true, "seat": seat{Row: 2}}); err != nil {
+		t.Fatal(err)
+	}
+	st := s.Status()
+	if st.GetString("uid") != "u1" || st.GetInt("level") != 3 || !st.GetBool("vip") {
+		t.Fatalf("merged status %v", st)
+	}
+	var got seat
+	if err := st.Unmarshal("seat", &got); err != nil || got.Row != 2 {
+		t.Fatalf("seat %v, err:%v", got, err)
+	}
+	if err := s.DeleteStatus(ctx, "vip"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ws.Status("s1")["vip"]; ok {
+		t.Fatal("vip was not deleted")
+	}
+	st, err := s.GetStatus(ctx, "uid", "vip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st) != 1 || st.GetString("uid") != "u1" {
+		t.Fatalf("partial status %v", st)
+	}
+	if s.Status().GetInt64("level") != 3 {
+		t.Fatalf("local status %v", s.Status())
+	}
+}
+
+func TestEncodeStatus(t *testing.T) {
+	v, err := session.EncodeStatus(session.Status{"uid": "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := session.DecodeStatus(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.GetString("uid") != "u1" {
+		t.Fatalf("decoded %v", st)
+	}
+}
@@ -0,0 +1,118 @@
+package session
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+)
+
+// Status is the key/value state the websocket server keeps for a session. The
+// values are strings on the wire, FormatStatus encodes the other types and the
+// Get helpers parse them back.
+type Status map[string]string
+
+func (s Status) GetString(key string) string {
+	return s[key]
+}
+
+func (s Status) GetInt(key string) int {
+	return int(s.GetInt64(key))
+}
+
+func (s Status) GetInt64(key string) int64 {
+	i, _ := strconv.ParseInt(s[key], 10, 64)
+	return i
+}
+
+func (s Status) GetFloat64(key string) float64 {
+	f, _ := strconv.ParseFloat(s[key], 64)
+	return f
+}
+
+func (s Status) GetBool(key string) bool {
+	b, _ := strconv.ParseBool(s[key])
+	return b
+}
+
+// Unmarshal decodes a value stored by SetStatus as JSON, e.g. a struct
+func (s Status) Unmarshal(key string, v interface{}) error {
+	b, ok := s[key]
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal([]byte(b), v)
+}
+
+// Copy returns a copy of s, never nil
+func (s Status) Copy() Status {
+	c := make(Status, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+// FormatStatus encodes v the way SetStatus stores it: strings as is, numbers
+// and booleans with strconv, everything else as JSON
+func FormatStatus(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncodeStatus encodes s for the micro-ws-session-status metadata
+func EncodeStatus(s Status) (string, error) {
+	if len(s) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecodeStatus decodes the micro-ws-session-status metadata
+func DecodeStatus(v string) (Status, error) {
+	s := make(Status)
+	if len(v) == 0 {
+		return s, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	if s == nil {
+		s = make(Status)
+	}
+	return s, nil
+}
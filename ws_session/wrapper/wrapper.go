@@ -3,20 +3,41 @@ package wrapper
 import (
 	"context"
 	"github.com/micro/micro/v3/service/context/metadata"
+	"github.com/micro/micro/v3/service/logger"
 	"github.com/micro/micro/v3/service/server"
 	"github.com/wolfplus2048/mcbeam-plugins/ws_session/v3"
 	cli "github.com/wolfplus2048/mcbeam-plugins/ws_session/v3/client"
 )
 
-func SessionHandler() server.HandlerWrapper {
+// SessionHandler places the websocket session in the context, opts are applied
+// to every session, e.g. session.Codec for clients speaking JSON, before the
+// ones the websocket server sets in the metadata
+func SessionHandler(opts ...session.Option) server.HandlerWrapper {
 	return func(h server.HandlerFunc) server.HandlerFunc {
 		return func(ctx context.Context, req server.Request, rsp interface{}) error {
 			sessionID, sok := metadata.Get(ctx, "micro-ws-session-id")
 			serverID, fok := metadata.Get(ctx, "micro-ws-server-id")
-			if !sok || !fok{
+			if !sok || !fok {
 				return h(ctx, req, rsp)
 			}
-			s := cli.NewSession(session.ServerID(serverID), session.SessionID(sessionID))
+			// the session inherits the inbound context so deadlines and trace
+			// metadata reach the websocket service
+			sopts := append([]session.Option{session.ServerID(serverID), session.SessionID(sessionID), session.Context(ctx)}, opts...)
+			// the websocket server tells its service name when it isn't the
+			// default one
+			if name, ok := metadata.Get(ctx, "micro-ws-service-name"); ok && name != "" {
+				sopts = append(sopts, session.ServiceName(name))
+			}
+			// the status the websocket server had when it forwarded the request
+			if v, ok := metadata.Get(ctx, "micro-ws-session-status"); ok && v != "" {
+				status, err := session.DecodeStatus(v)
+				if err != nil {
+					logger.Warnf("decode session status, sid:%s, err:%v", sessionID, err)
+				} else {
+					sopts = append(sopts, session.InitialStatus(status))
+				}
+			}
+			s := cli.NewSession(sopts...)
 			ctx = context.WithValue(ctx, session.SessionCtxKey{}, s)
 			return h(ctx, req, rsp)
 		}